@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 )
@@ -36,6 +37,46 @@ type chatCompletionResponse struct {
 	} `json:"choices"`
 }
 
+// translationCache 翻译缓存：同一内容（如多个订阅的同一 Release）只翻译一次
+var translationCache = struct {
+	mu      sync.Mutex
+	entries map[string]cachedTranslation
+}{entries: make(map[string]cachedTranslation)}
+
+type cachedTranslation struct {
+	text    string
+	expires time.Time
+}
+
+// getCachedTranslation 读取缓存的译文
+func getCachedTranslation(text string) (string, bool) {
+	translationCache.mu.Lock()
+	defer translationCache.mu.Unlock()
+	entry, ok := translationCache.entries[text]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false
+	}
+	return entry.text, true
+}
+
+// putCachedTranslation 写入译文缓存，超出容量时先清理过期项，仍然超出则清空
+func putCachedTranslation(text, translated string) {
+	translationCache.mu.Lock()
+	defer translationCache.mu.Unlock()
+	if len(translationCache.entries) >= translationCacheSize {
+		now := time.Now()
+		for k, v := range translationCache.entries {
+			if now.After(v.expires) {
+				delete(translationCache.entries, k)
+			}
+		}
+		if len(translationCache.entries) >= translationCacheSize {
+			translationCache.entries = make(map[string]cachedTranslation)
+		}
+	}
+	translationCache.entries[text] = cachedTranslation{text: translated, expires: time.Now().Add(translationCacheTTL)}
+}
+
 // init
 func initAI(apiKey, baseURL, model string) {
 	aiConfig.APIKey = strings.TrimSpace(apiKey)
@@ -83,6 +124,11 @@ func translateText(text string) (string, error) {
 		return "", nil
 	}
 
+	if cached, ok := getCachedTranslation(text); ok {
+		Logger.Debug("🤖 AI translation cache hit: %.50s...", text)
+		return cached, nil
+	}
+
 	Logger.Debug("🤖 AI translating: %.50s...", text)
 
 	reqBody := chatCompletionRequest{
@@ -140,6 +186,7 @@ func translateText(text string) (string, error) {
 	}
 
 	translated := aiResp.Choices[0].Message.Content
+	putCachedTranslation(text, translated)
 	Logger.Debug("✅ AI translation done")
 	return translated, nil
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// validAssetFilter 校验附件 glob（多个用逗号分隔）
+func validAssetFilter(filter string) bool {
+	for _, pattern := range strings.Split(filter, ",") {
+		if pattern == "" {
+			return false
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSize 解析文件大小，如 20MB、512KB、1048576
+func parseSize(s string) (int64, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"MB", 1 << 20}, {"M", 1 << 20}, {"KB", 1 << 10}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSuffix(s, u.suffix), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return int64(n * float64(unit)), true
+}
+
+// formatSize 格式化文件大小
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return strconv.FormatFloat(float64(size)/(1<<20), 'f', -1, 64) + " MB"
+	case size >= 1<<10:
+		return strconv.FormatFloat(float64(size)/(1<<10), 'f', 0, 64) + " KB"
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+// matchReleaseAssets 按订阅的 glob 和大小上限筛选要上传的附件，最多 maxReleaseAssets 个
+func matchReleaseAssets(assets []gitHubAsset, cfg *repoConfig) []gitHubAsset {
+	if cfg.AssetFilter == "" {
+		return nil
+	}
+	maxSize := cfg.AssetMaxSize
+	if maxSize <= 0 || maxSize > telegramMaxUploadSize {
+		maxSize = telegramMaxUploadSize
+	}
+	patterns := strings.Split(cfg.AssetFilter, ",")
+
+	var matched []gitHubAsset
+	for _, asset := range assets {
+		ok := false
+		for _, pattern := range patterns {
+			if m, _ := path.Match(pattern, asset.Name); m {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		if asset.Size > maxSize {
+			log.Printf("  ⚠️ Skipping asset %s (%s): larger than %s", asset.Name, formatSize(asset.Size), formatSize(maxSize))
+			continue
+		}
+		if len(matched) >= maxReleaseAssets {
+			log.Printf("  ⚠️ More than %d matching assets for %s, skipping the rest", maxReleaseAssets, cfg.Repo)
+			break
+		}
+		matched = append(matched, asset)
+	}
+	return matched
+}
+
+// uploadReleaseAssets 下载附件并作为文档回复到通知消息下
+// 附件按批上传（每批最多 10 个且总大小不超过上传上限），下载内容直接写入上传请求，不落盘也不整体读入内存
+// Telegram 拒绝或已无法下载的附件会跳过，只有可重试的错误才返回；silent 时与通知一样静默发送
+// 返回已处理完（上传成功或跳过）的附件名，出错时调用方据此记录进度，重试时不再重复上传
+func uploadReleaseAssets(tg *telegramClient, chatID, threadID int64, replyTo int, repo string, assets []gitHubAsset, silent bool) (done []string, err error) {
+	var batches [][]gitHubAsset
+	var size int64
+	for _, asset := range assets {
+		n := len(batches)
+		if n == 0 || len(batches[n-1]) >= maxReleaseAssets || size+asset.Size > telegramMaxUploadSize {
+			batches = append(batches, nil)
+			n++
+			size = 0
+		}
+		batches[n-1] = append(batches[n-1], asset)
+		size += asset.Size
+	}
+
+	for _, batch := range batches {
+		ctx, cancel := context.WithTimeout(context.Background(), assetUploadTimeout)
+		files := make([]uploadFile, len(batch))
+		for i, asset := range batch {
+			id := asset.ID
+			files[i] = uploadFile{
+				name: asset.Name,
+				open: func() (io.ReadCloser, error) {
+					return githubAPI.DownloadAsset(ctx, repo, id)
+				},
+			}
+		}
+		err = tg.sendDocuments(chatID, threadID, replyTo, files, silent)
+		cancel()
+
+		var (
+			tgErr *telegramError
+			ghErr *gitHubError
+		)
+		switch {
+		case err == nil:
+			log.Printf("📎 Uploaded %d asset(s) of %s to %d", len(batch), repo, chatID)
+		case errors.As(err, &tgErr) && !isRetryableTelegramError(err):
+			log.Printf("⚠️ Telegram rejected assets of %s, skipping: %v", repo, err)
+		case errors.As(err, &ghErr) && ghErr.Permanent:
+			log.Printf("⚠️ Failed to download assets of %s, skipping: %v", repo, err)
+		default:
+			return done, err
+		}
+		for _, asset := range batch {
+			done = append(done, asset.Name)
+		}
+	}
+	return done, nil
+}
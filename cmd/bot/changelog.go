@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// changelogFiles Release 正文为空时依次尝试的更新日志文件
+var changelogFiles = []string{"CHANGELOG.md", "CHANGELOG", "CHANGES.md", "HISTORY.md"}
+
+var (
+	markdownHeadingRegexp = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	headingVersionRegexp  = regexp.MustCompile(`v?\d+(?:\.\d+)+(?:-[0-9A-Za-z.-]+)?`)
+)
+
+// changelogNotes 从仓库 tag 下的 CHANGELOG 中提取对应版本的更新日志，找不到时返回空字符串
+func changelogNotes(ctx context.Context, repo, tag string) (string, error) {
+	version := headingVersionRegexp.FindString(tag)
+	if version == "" {
+		return "", nil
+	}
+	for _, name := range changelogFiles {
+		text, err := githubAPI.FileContent(ctx, repo, name, tag)
+		if err != nil {
+			return "", err
+		}
+		if text == "" {
+			continue
+		}
+		if section := changelogSection(text, version); section != "" {
+			Logger.Debug("  📄 Using %s section for %s@%s", name, repo, tag)
+			return truncateRunes(section, maxChangelogRunes), nil
+		}
+	}
+	return "", nil
+}
+
+// changelogSection 提取指定版本的章节内容
+// 兼容 Keep a Changelog（## [1.2.3] - 2024-01-01）以及 ## v1.2.3、## 1.2.3 (2024-01-01) 等写法，
+// 章节在下一个同级或更高级标题处结束
+func changelogSection(text, version string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	start, level := -1, 0
+	for i, line := range lines {
+		m := markdownHeadingRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		if start >= 0 {
+			if len(m[1]) <= level {
+				return strings.TrimSpace(strings.Join(lines[start:i], "\n"))
+			}
+			continue
+		}
+		if v := headingVersionRegexp.FindString(m[2]); v != "" && compareVersions(v, version) == 0 {
+			start, level = i+1, len(m[1])
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.TrimSpace(strings.Join(lines[start:], "\n"))
+}
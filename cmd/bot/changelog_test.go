@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestChangelogSection(t *testing.T) {
+	const keepAChangelog = `# Changelog
+
+## [Unreleased]
+
+- Pending
+
+## [1.2.0] - 2024-02-01
+
+### Added
+
+- Feature
+
+### Fixed
+
+- Bug
+
+## [1.1.0] - 2024-01-01
+
+- Older
+`
+	tests := []struct {
+		name, text, version, want string
+	}{
+		{"keep a changelog", keepAChangelog, "v1.2.0", "### Added\n\n- Feature\n\n### Fixed\n\n- Bug"},
+		{"last section", keepAChangelog, "1.1.0", "- Older"},
+		{"missing version", keepAChangelog, "1.3.0", ""},
+		{"v prefix heading", "## v2.0.0\r\n\r\n* Breaking\r\n## v1.9.0\r\n* Old", "2.0.0", "* Breaking"},
+		{"date suffix", "# 3.1.0 (2024-05-01)\nNotes\n# 3.0.0\nOld", "v3.1.0", "Notes"},
+		{"prerelease", "## 1.0.0-rc.1\nCandidate\n## 1.0.0\nFinal", "1.0.0", "Final"},
+		{"lower heading ends nothing", "## 1.0.0\nA\n#### 1.0.1 notes\nB", "1.0.0", "A\n#### 1.0.1 notes\nB"},
+	}
+	for _, tt := range tests {
+		if got := changelogSection(tt.text, tt.version); got != tt.want {
+			t.Errorf("%s: changelogSection(%q) = %q, want %q", tt.name, tt.version, got, tt.want)
+		}
+	}
+}
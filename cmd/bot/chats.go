@@ -0,0 +1,118 @@
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// migrateChat 群组升级为超级群组后，将指向旧 ID 的订阅和待发送通知改到新 ID，并通知管理员迁移了哪些订阅
+// 同一次迁移可能被多条发送同时发现，只有第一次会改到订阅，之后的调用直接返回
+func migrateChat(tg *telegramClient, adminID, oldID, newID int64) {
+	var (
+		title string
+		moved []string
+	)
+	err := updateConfigs(func(configs []repoConfig) ([]repoConfig, bool) {
+		for i := range configs {
+			if configs[i].ChannelID != oldID {
+				continue
+			}
+			configs[i].ChannelID = newID
+			title = configs[i].ChannelTitle
+			moved = append(moved, subscriptionName(&configs[i]))
+		}
+		return configs, len(moved) > 0
+	})
+	migrateOutboxChat(oldID, newID)
+	if err != nil {
+		log.Printf("❌ Failed to migrate subscriptions from chat %d to %d: %v", oldID, newID, err)
+		return
+	}
+	if len(moved) == 0 {
+		return
+	}
+
+	log.Printf("🔀 Chat %d migrated to %d, moved %d subscription(s)", oldID, newID, len(moved))
+	if _, err := tg.sendMessage(adminID, Messages.ChatMigrated(title, oldID, newID, moved), telegramParseModeMarkdown, true, "", 0); err != nil {
+		log.Printf("⚠️ Failed to notify admin about chat migration: %v", err)
+	}
+}
+
+// hasChatAccess 判断机器人在该成员状态下能否在会话中发消息
+// 频道需要管理员的发消息权限，群组只要是成员且未被禁言即可
+func hasChatAccess(c *chat, m *chatMember) bool {
+	switch m.Status {
+	case "creator":
+		return true
+	case "administrator":
+		return c.Type != "channel" || m.CanPostMessages
+	case "member":
+		return c.Type != "channel"
+	case "restricted":
+		return m.IsMember && m.CanSendMessages
+	}
+	return false
+}
+
+// handleMyChatMember 处理机器人成员状态变化：失去发消息权限时自动暂停该会话的订阅并私聊管理员，
+// 权限恢复后只恢复自动暂停的订阅（管理员手动暂停的保持不变）；发件箱中发往该会话的通知同样暂停和恢复
+func handleMyChatMember(tg *telegramClient, upd *chatMemberUpdated, adminID int64) {
+	if upd.Chat == nil || upd.Chat.Type == "private" {
+		return
+	}
+	access := hasChatAccess(upd.Chat, &upd.NewChatMember)
+	if access == hasChatAccess(upd.Chat, &upd.OldChatMember) {
+		return
+	}
+	Logger.Debug("👥 Bot status in %d (%s): %s -> %s", upd.Chat.ID, upd.Chat.Title, upd.OldChatMember.Status, upd.NewChatMember.Status)
+	holdOutboxChat(upd.Chat.ID, !access)
+	// 新加入的群组注册管理员的群组命令菜单（频道不支持）
+	if access && upd.Chat.Type != "channel" {
+		if err := registerGroupCommands(tg, adminID, upd.Chat.ID); err != nil {
+			log.Printf("⚠️ Failed to register command menu in %s: %v", upd.Chat.Title, err)
+		}
+	}
+
+	var names []string
+	err := updateConfigs(func(configs []repoConfig) ([]repoConfig, bool) {
+		for i := range configs {
+			cfg := &configs[i]
+			if cfg.ChannelID != upd.Chat.ID {
+				continue
+			}
+			switch {
+			case !access && !cfg.Paused:
+				cfg.Paused, cfg.AutoPaused = true, true
+			case access && cfg.AutoPaused:
+				cfg.Paused, cfg.AutoPaused = false, false
+			default:
+				continue
+			}
+			names = append(names, subscriptionName(cfg))
+		}
+		return configs, len(names) > 0
+	})
+	if err != nil {
+		log.Printf("❌ Failed to update subscriptions for chat %d: %v", upd.Chat.ID, err)
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	var msg string
+	if access {
+		log.Printf("▶️ Access to %s restored, resumed %d subscription(s)", upd.Chat.Title, len(names))
+		msg = Messages.ChatAccessRestored(upd.Chat.Title, names)
+	} else {
+		log.Printf("⏸️ Lost access to %s (%s), paused %d subscription(s)", upd.Chat.Title, upd.NewChatMember.Status, len(names))
+		by := ""
+		if upd.From != nil {
+			by = strings.TrimSpace(upd.From.FirstName + " " + upd.From.LastName)
+		}
+		msg = Messages.ChatAccessLost(upd.Chat.Title, by, names)
+	}
+	if _, err := tg.sendMessage(adminID, msg, telegramParseModeMarkdown, true, "", 0); err != nil {
+		log.Printf("⚠️ Failed to notify admin about chat access change: %v", err)
+	}
+}
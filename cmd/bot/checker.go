@@ -0,0 +1,772 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checkEvent 检查的事件类型
+type checkEvent string
+
+const (
+	eventRelease checkEvent = "release"
+	eventCommit  checkEvent = "commit"
+	eventTags    checkEvent = "tags"    // 容器镜像新标签
+	eventDigest  checkEvent = "digest"  // 容器镜像标签 digest 变化
+	eventVersion checkEvent = "version" // 语言包新版本
+	eventFeed    checkEvent = "feed"    // RSS/Atom 新条目
+	eventStars   checkEvent = "stars"   // GitHub 用户的新 Star
+)
+
+// checkGroupKey 订阅分组键：同组订阅共享一次上游请求和一次翻译
+type checkGroupKey struct {
+	source string
+	repo   string
+	branch string
+	event  checkEvent
+}
+
+// checkJob 一个分组检查任务
+type checkJob struct {
+	key  checkGroupKey
+	subs []repoConfig
+}
+
+// subUpdate 单个订阅在某个事件类型上的检查结果
+type subUpdate struct {
+	id      int64
+	event   checkEvent
+	cfg     repoConfig // 检查后的订阅副本
+	changed bool
+	eventAt time.Time // 检测到新事件的时间，没有则为零值
+	err     error     // 获取上游失败时的错误，调度器据此区分永久性/临时性错误
+}
+
+// groupCheckJobs 将订阅按 (仓库, 分支, 事件类型) 分组，跳过已暂停的订阅
+// 同时监控 Release 和 Commit 的订阅会出现在两个分组中
+func groupCheckJobs(subs []repoConfig) []checkJob {
+	var jobs []checkJob
+	index := make(map[checkGroupKey]int)
+	add := func(key checkGroupKey, cfg repoConfig) {
+		if i, ok := index[key]; ok {
+			jobs[i].subs = append(jobs[i].subs, cfg)
+			return
+		}
+		index[key] = len(jobs)
+		jobs = append(jobs, checkJob{key: key, subs: []repoConfig{cfg}})
+	}
+
+	for _, cfg := range subs {
+		if cfg.Paused {
+			continue
+		}
+		switch cfg.Source {
+		case sourceImage:
+			add(checkGroupKey{source: cfg.Source, repo: cfg.Repo, event: eventTags}, cfg)
+			if cfg.WatchDigest {
+				add(checkGroupKey{source: cfg.Source, repo: cfg.Repo, branch: cfg.Branch, event: eventDigest}, cfg)
+			}
+		case sourceGo, sourceNpm, sourcePyPI, sourceCrates:
+			add(checkGroupKey{source: cfg.Source, repo: cfg.Repo, event: eventVersion}, cfg)
+		case sourceFeed:
+			add(checkGroupKey{source: cfg.Source, repo: cfg.Repo, event: eventFeed}, cfg)
+		case sourceStars:
+			add(checkGroupKey{source: cfg.Source, repo: cfg.Repo, event: eventStars}, cfg)
+		default:
+			if cfg.MonitorRelease {
+				add(checkGroupKey{repo: cfg.Repo, event: eventRelease}, cfg)
+			}
+			if cfg.MonitorCommit {
+				add(checkGroupKey{repo: cfg.Repo, branch: cfg.Branch, event: eventCommit}, cfg)
+			}
+		}
+	}
+	return jobs
+}
+
+// runCheckJob 执行一个分组检查任务
+func runCheckJob(tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	if len(job.subs) > 1 {
+		Logger.Debug("📦 Checking %s %s for %d subscriptions", job.key.repo, job.key.event, len(job.subs))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), checkJobTimeout)
+	defer cancel()
+
+	switch job.key.event {
+	case eventRelease:
+		return checkReleaseGroup(ctx, tg, adminID, job)
+	case eventCommit:
+		return checkCommitGroup(ctx, tg, adminID, job)
+	case eventTags:
+		return checkImageTagsGroup(ctx, tg, adminID, job)
+	case eventDigest:
+		return checkImageDigestGroup(ctx, tg, adminID, job)
+	case eventVersion:
+		return checkPackageGroup(ctx, tg, adminID, job)
+	case eventFeed:
+		return checkFeedGroup(ctx, tg, adminID, job)
+	case eventStars:
+		return checkStarsGroup(ctx, tg, adminID, job)
+	}
+	return unchangedUpdates(job)
+}
+
+// failedUpdates 返回组内所有订阅"获取失败"的结果
+func failedUpdates(updates []subUpdate, err error) []subUpdate {
+	for i := range updates {
+		updates[i].err = err
+	}
+	return updates
+}
+
+// unchangedUpdates 返回组内所有订阅"无变化"的结果
+func unchangedUpdates(job checkJob) []subUpdate {
+	updates := make([]subUpdate, len(job.subs))
+	for i, cfg := range job.subs {
+		updates[i] = subUpdate{id: cfg.ID, event: job.key.event, cfg: cfg}
+	}
+	return updates
+}
+
+// applyCheckState 将某个事件类型的检查结果应用到订阅
+func applyCheckState(dst *repoConfig, u *subUpdate) {
+	switch u.event {
+	case eventRelease:
+		dst.LastReleaseID = u.cfg.LastReleaseID
+		dst.LastReleaseTag = u.cfg.LastReleaseTag
+	case eventCommit:
+		dst.LastCommitSHA = u.cfg.LastCommitSHA
+		if dst.Branch == "" {
+			dst.Branch = u.cfg.Branch
+		}
+		if dst.RepoName == "" {
+			dst.RepoName = u.cfg.RepoName
+		}
+	case eventTags:
+		dst.KnownTags = u.cfg.KnownTags
+		dst.SeenRecorded = u.cfg.SeenRecorded
+	case eventDigest:
+		dst.LastDigest = u.cfg.LastDigest
+	case eventVersion:
+		dst.LastVersion = u.cfg.LastVersion
+	case eventFeed, eventStars:
+		dst.SeenItems = u.cfg.SeenItems
+		dst.StarFailures = u.cfg.StarFailures
+		dst.SeenRecorded = u.cfg.SeenRecorded
+	}
+	if !u.eventAt.IsZero() {
+		recordEvent(dst, u.eventAt)
+	}
+}
+
+// notifyTarget 返回订阅的通知目标（未指定频道时发给管理员）
+func notifyTarget(cfg *repoConfig, adminID int64) int64 {
+	if cfg.ChannelID == 0 {
+		return adminID
+	}
+	return cfg.ChannelID
+}
+
+// queueNotification 将通知写入发件箱，由后台发送并在失败时重试；event 为事件标识，用于去重
+// 写入失败时记录到 u.err 并返回 false，调用方不推进该订阅的状态，下次检查时重新检测
+func queueNotification(adminID int64, u *subUpdate, kind, event, msg string, assets []gitHubAsset) bool {
+	targetID := notifyTarget(&u.cfg, adminID)
+	key := fmt.Sprintf("%s:%d:%s", kind, u.id, event)
+	Logger.Debug("  📤 Queueing %s notification to %d (topic: %d)", kind, targetID, u.cfg.ThreadID)
+	if err := enqueueNotification(&u.cfg, targetID, key, msg, assets); err != nil {
+		log.Printf("  ❌ Failed to queue %s notification for %s: %v", kind, u.cfg.Repo, err)
+		u.err = err
+		return false
+	}
+	return true
+}
+
+// checkReleaseGroup 检查 Release：每组只请求一次、只翻译一次，再分发给各订阅
+func checkReleaseGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	repo := job.key.repo
+	updates := unchangedUpdates(job)
+
+	Logger.Debug("  🔍 Checking releases for %s", repo)
+	release, err := githubAPI.LatestRelease(ctx, repo)
+	if err != nil {
+		log.Printf("  ❌ Error fetching release for %s: %v", repo, err)
+		return failedUpdates(updates, err)
+	}
+	if release == nil {
+		Logger.Debug("  ℹ️ No releases found for %s", repo)
+		return updates
+	}
+
+	// 更新日志和翻译按需获取，整组共用
+	var notesLoaded bool
+	var releaseBody, releaseTranslation string
+	loadNotes := func() {
+		if notesLoaded {
+			return
+		}
+		notesLoaded = true
+		log.Printf("🆕 New release: %s@%s", repo, release.TagName)
+
+		// Release 正文为空时回退到 tag 下 CHANGELOG 中对应版本的章节
+		body := strings.TrimSpace(release.Body)
+		if body == "" {
+			notes, err := changelogNotes(ctx, repo, release.TagName)
+			if err != nil {
+				Logger.Debug("  ⚠️ Failed to read changelog for %s@%s: %v", repo, release.TagName, err)
+			}
+			body = notes
+		}
+
+		// AI 翻译更新日志（如果有且非中文）
+		if body != "" {
+			releaseBody = body
+			if translated, err := translateText(body); err != nil {
+				Logger.Debug("  ⚠️ AI translation failed for release body: %v", err)
+			} else if translated != "" {
+				releaseTranslation = translated
+			}
+		}
+	}
+
+	// 通知内容按上一个 Release 的 tag 缓存（空字符串表示不附带提交记录）
+	messages := make(map[string]string)
+	buildMessage := func(prevTag string) string {
+		if msg, ok := messages[prevTag]; ok {
+			return msg
+		}
+		loadNotes()
+		var changes string
+		if prevTag != "" {
+			var err error
+			if changes, err = releaseCommitRange(ctx, repo, prevTag, release.TagName); err != nil {
+				Logger.Debug("  ⚠️ Failed to compare %s...%s for %s: %v", prevTag, release.TagName, repo, err)
+			}
+		}
+		msg := Messages.NotifyRelease(repo, release.TagName, releaseBody, releaseTranslation, changes, release.HTMLURL)
+		messages[prevTag] = msg
+		return msg
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		if u.cfg.LastReleaseID != nil && *u.cfg.LastReleaseID == release.ID {
+			Logger.Debug("  ✓ No new release for %s (subscription %d)", repo, u.id)
+			continue
+		}
+		// 每个订阅独立记录状态，新订阅首次只记录不通知
+		if u.cfg.LastReleaseID != nil {
+			prevTag := ""
+			if u.cfg.ShowCommits {
+				prevTag = previousReleaseTag(ctx, repo, &u.cfg)
+			}
+			if !queueNotification(adminID, u, "release", strconv.FormatInt(release.ID, 10), buildMessage(prevTag), matchReleaseAssets(release.Assets, &u.cfg)) {
+				continue
+			}
+			u.eventAt = time.Now()
+		} else {
+			Logger.Debug("  ℹ️ Initial release recorded for %s: %s (ID: %d)", repo, release.TagName, release.ID)
+		}
+		latestID, latestTag := release.ID, release.TagName
+		u.cfg.LastReleaseID = &latestID
+		u.cfg.LastReleaseTag = &latestTag
+		u.changed = true
+	}
+	return updates
+}
+
+// previousReleaseTag 返回订阅记录的上一个 Release 的 tag
+// 旧配置只记录了 Release ID，此时按 ID 查询；查询失败时返回空字符串（不附带提交记录）
+func previousReleaseTag(ctx context.Context, repo string, cfg *repoConfig) string {
+	if cfg.LastReleaseTag != nil {
+		return *cfg.LastReleaseTag
+	}
+	prev, err := githubAPI.ReleaseByID(ctx, repo, *cfg.LastReleaseID)
+	if err != nil {
+		Logger.Debug("  ⚠️ Failed to get previous release %d for %s: %v", *cfg.LastReleaseID, repo, err)
+		return ""
+	}
+	if prev == nil {
+		return ""
+	}
+	return prev.TagName
+}
+
+// checkCommitGroup 检查 Commit：每组只请求一次、只翻译一次，再分发给各订阅
+func checkCommitGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	repo := job.key.repo
+	updates := unchangedUpdates(job)
+
+	branch := job.key.branch
+	if branch == "" {
+		Logger.Debug("  🔍 Fetching repo info for %s", repo)
+		repoName := ""
+		info, err := githubAPI.RepoInfo(ctx, repo)
+		if isPermanentError(err) {
+			log.Printf("  ❌ Error fetching repo info for %s: %v", repo, err)
+			return failedUpdates(updates, err)
+		}
+		if err != nil {
+			log.Printf("  ⚠️ Failed to get repo info for %s, using 'main': %v", repo, err)
+			branch = "main"
+		} else {
+			branch = info.DefaultBranch
+			repoName = info.Name
+		}
+		// 缓存到配置，下次无需再请求 API
+		for i := range updates {
+			updates[i].cfg.Branch = branch
+			if updates[i].cfg.RepoName == "" {
+				updates[i].cfg.RepoName = repoName
+			}
+			updates[i].changed = true
+		}
+	}
+
+	Logger.Debug("  🔍 Checking commits for %s:%s", repo, branch)
+	commit, err := githubAPI.LatestCommit(ctx, repo, branch)
+	if err != nil {
+		log.Printf("  ❌ Error fetching commit for %s:%s: %v", repo, branch, err)
+		return failedUpdates(updates, err)
+	}
+	if commit == nil {
+		Logger.Debug("  ℹ️ No commits found for %s:%s", repo, branch)
+		return updates
+	}
+
+	// 通知内容按需构建，整组共用
+	var msg string
+	buildMessage := func() string {
+		if msg != "" {
+			return msg
+		}
+		log.Printf("🆕 New commit: %s:%s@%.7s", repo, branch, commit.SHA)
+		message := strings.TrimSpace(commit.Commit.Message)
+		if message == "" {
+			message = commit.SHA
+		}
+
+		// AI 翻译
+		var translation string
+		if translated, err := translateText(message); err != nil {
+			Logger.Debug("  ⚠️ AI translation failed: %v", err)
+		} else if translated != "" {
+			translation = translated
+		}
+
+		repoName := repo
+		if parts := strings.Split(repo, "/"); len(parts) == 2 {
+			repoName = parts[1]
+		}
+
+		// 使用 Messages 构建消息
+		msg = Messages.NotifyCommit(repoName, branch, message, translation, commit.HTMLURL)
+		return msg
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		if u.cfg.LastCommitSHA != nil && *u.cfg.LastCommitSHA == commit.SHA {
+			Logger.Debug("  ✓ No new commit for %s:%s (subscription %d)", repo, branch, u.id)
+			continue
+		}
+		// 每个订阅独立记录状态，新订阅首次只记录不通知
+		if u.cfg.LastCommitSHA != nil {
+			if !queueNotification(adminID, u, "commit", commit.SHA, buildMessage(), nil) {
+				continue
+			}
+			u.eventAt = time.Now()
+		} else {
+			Logger.Debug("  ℹ️ Initial commit recorded for %s:%s: %.7s", repo, branch, commit.SHA)
+		}
+		latestSHA := commit.SHA
+		u.cfg.LastCommitSHA = &latestSHA
+		u.changed = true
+	}
+	return updates
+}
+
+// checkImageTagsGroup 检查容器镜像新标签：每组只列一次标签，再按各订阅的标签过滤分发
+func checkImageTagsGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	updates := unchangedUpdates(job)
+	ref, ok := parseImageRef(job.key.repo)
+	if !ok {
+		log.Printf("  ❌ Invalid image reference in config: %s", job.key.repo)
+		return updates
+	}
+
+	Logger.Debug("  🔍 Checking tags for %s", ref)
+	tags, err := registryAPI.ListTags(ctx, ref)
+	if err != nil {
+		log.Printf("  ❌ Error listing tags for %s: %v", ref, err)
+		return failedUpdates(updates, err)
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		matched := tags
+		if u.cfg.TagFilter != "" {
+			filter, err := regexp.Compile(u.cfg.TagFilter)
+			if err != nil {
+				log.Printf("  ⚠️ Invalid tag filter %q for subscription %d: %v", u.cfg.TagFilter, u.id, err)
+				continue
+			}
+			matched = nil
+			for _, tag := range tags {
+				if filter.MatchString(tag) {
+					matched = append(matched, tag)
+				}
+			}
+		}
+
+		// 每个订阅独立记录状态，新订阅首次只记录不通知
+		kept := rememberTags(matched)
+		if !seenRecorded(&u.cfg, u.cfg.KnownTags) {
+			Logger.Debug("  ℹ️ Initial tags recorded for %s: %d tag(s)", ref, len(matched))
+			u.cfg.KnownTags = kept
+			u.cfg.SeenRecorded = true
+			u.changed = true
+			continue
+		}
+
+		newTags := diffTags(u.cfg.KnownTags, matched)
+		if len(newTags) == 0 && slices.Equal(kept, u.cfg.KnownTags) && u.cfg.SeenRecorded {
+			Logger.Debug("  ✓ No new tags for %s (subscription %d)", ref, u.id)
+			continue
+		}
+
+		if len(newTags) > 0 {
+			sortVersionsDesc(newTags)
+			log.Printf("🆕 New tag(s) for %s: %s", ref, strings.Join(newTags, ", "))
+			msg := Messages.NotifyImageTags(ref.String(), newTags, ref.WebURL())
+			if !queueNotification(adminID, u, "tag", strings.Join(newTags, ","), msg, nil) {
+				continue
+			}
+			u.eventAt = time.Now()
+		}
+		u.cfg.KnownTags = kept
+		u.cfg.SeenRecorded = true
+		u.changed = true
+	}
+	return updates
+}
+
+// seenRecorded 判断订阅是否已记录过初始的标签/条目列表（兼容没有 SeenRecorded 字段的旧配置）
+func seenRecorded(cfg *repoConfig, list []string) bool {
+	return cfg.SeenRecorded || list != nil
+}
+
+// rememberTags 返回要保存的已知标签：按版本从新到旧只保留 maxKnownTags 个
+func rememberTags(tags []string) []string {
+	kept := append([]string{}, tags...)
+	sortVersionsDesc(kept)
+	if len(kept) > maxKnownTags {
+		kept = kept[:maxKnownTags]
+	}
+	return kept
+}
+
+// diffTags 返回 tags 中的新标签
+// 已知标签只保留最新的 maxKnownTags 个，保留数已满时比最旧的已知标签还旧的标签视为旧标签
+func diffTags(known, tags []string) []string {
+	seen := make(map[string]bool, len(known))
+	oldest := ""
+	for i, tag := range known {
+		seen[tag] = true
+		if i == 0 || compareVersions(tag, oldest) < 0 {
+			oldest = tag
+		}
+	}
+	var newTags []string
+	for _, tag := range tags {
+		if seen[tag] {
+			continue
+		}
+		if len(known) >= maxKnownTags && compareVersions(tag, oldest) <= 0 {
+			continue
+		}
+		newTags = append(newTags, tag)
+	}
+	return newTags
+}
+
+// checkImageDigestGroup 检查浮动标签（如 latest）的 digest 是否变化
+func checkImageDigestGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	updates := unchangedUpdates(job)
+	ref, ok := parseImageRef(job.key.repo)
+	if !ok {
+		log.Printf("  ❌ Invalid image reference in config: %s", job.key.repo)
+		return updates
+	}
+	tag := job.key.branch
+
+	Logger.Debug("  🔍 Checking digest for %s:%s", ref, tag)
+	digest, err := registryAPI.Digest(ctx, ref, tag)
+	if err != nil {
+		log.Printf("  ❌ Error fetching digest for %s:%s: %v", ref, tag, err)
+		return failedUpdates(updates, err)
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		if u.cfg.LastDigest != nil && *u.cfg.LastDigest == digest {
+			Logger.Debug("  ✓ Digest unchanged for %s:%s (subscription %d)", ref, tag, u.id)
+			continue
+		}
+		if u.cfg.LastDigest != nil {
+			log.Printf("🆕 Digest changed: %s:%s -> %s", ref, tag, digest)
+			msg := Messages.NotifyImageDigest(ref.String(), tag, *u.cfg.LastDigest, digest, ref.WebURL())
+			if !queueNotification(adminID, u, "digest", digest, msg, nil) {
+				continue
+			}
+			u.eventAt = time.Now()
+		} else {
+			Logger.Debug("  ℹ️ Initial digest recorded for %s:%s: %s", ref, tag, digest)
+		}
+		latest := digest
+		u.cfg.LastDigest = &latest
+		u.changed = true
+	}
+	return updates
+}
+
+// checkPackageGroup 检查语言包新版本：只在版本号变大时通知，避免撤回（yank）等导致的回退误报
+func checkPackageGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	updates := unchangedUpdates(job)
+	registry := packageRegistries[job.key.source]
+	name := job.key.repo
+
+	Logger.Debug("  🔍 Checking %s package %s", registry.Label, name)
+	latest, err := registry.latest(ctx, name)
+	if err != nil {
+		log.Printf("  ❌ Error fetching %s package %s: %v", registry.Label, name, err)
+		return failedUpdates(updates, err)
+	}
+	if latest == nil {
+		Logger.Debug("  ℹ️ No versions found for %s package %s", registry.Label, name)
+		return updates
+	}
+
+	display := job.key.source + ":" + name
+	for i := range updates {
+		u := &updates[i]
+		if u.cfg.LastVersion != nil && compareVersions(latest.Version, *u.cfg.LastVersion) <= 0 {
+			Logger.Debug("  ✓ No new version for %s (subscription %d)", display, u.id)
+			continue
+		}
+		// 每个订阅独立记录状态，新订阅首次只记录不通知
+		if u.cfg.LastVersion != nil {
+			log.Printf("🆕 New version: %s@%s", display, latest.Version)
+			msg := Messages.NotifyRelease(display, latest.Version, "", "", "", latest.URL)
+			if !queueNotification(adminID, u, "version", latest.Version, msg, nil) {
+				continue
+			}
+			u.eventAt = time.Now()
+		} else {
+			Logger.Debug("  ℹ️ Initial version recorded for %s: %s", display, latest.Version)
+		}
+		version := latest.Version
+		u.cfg.LastVersion = &version
+		u.changed = true
+	}
+	return updates
+}
+
+// checkFeedGroup 检查 RSS/Atom 新条目：按 GUID/ID 去重，每个条目整组只翻译一次
+func checkFeedGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	updates := unchangedUpdates(job)
+	feedURL := job.key.repo
+
+	Logger.Debug("  🔍 Checking feed %s", feedURL)
+	f, err := fetchFeed(ctx, feedURL)
+	if err != nil {
+		log.Printf("  ❌ Error fetching feed %s: %v", feedURL, err)
+		return failedUpdates(updates, err)
+	}
+
+	// 通知内容按条目缓存，整组共用
+	messages := make(map[string]string)
+	buildMessage := func(item feedItem) string {
+		if msg, ok := messages[item.ID]; ok {
+			return msg
+		}
+		log.Printf("🆕 New feed item: %s - %s", feedURL, item.Title)
+		content := truncateRunes(item.Content, maxFeedContentRunes)
+		var translation string
+		if content != "" {
+			if translated, err := translateText(content); err != nil {
+				Logger.Debug("  ⚠️ AI translation failed for feed item: %v", err)
+			} else {
+				translation = translated
+			}
+		}
+		msg := Messages.NotifyFeed(f.Title, item.Title, content, translation, item.Link)
+		messages[item.ID] = msg
+		return msg
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		seen := make(map[string]bool, len(u.cfg.SeenItems))
+		for _, id := range u.cfg.SeenItems {
+			seen[id] = true
+		}
+
+		// 订阅源通常从新到旧排列，通知时按从旧到新发送
+		var fresh []feedItem
+		for j := len(f.Items) - 1; j >= 0; j-- {
+			if item := f.Items[j]; item.ID != "" && !seen[item.ID] {
+				fresh = append(fresh, item)
+			}
+		}
+		recorded := seenRecorded(&u.cfg, u.cfg.SeenItems)
+		if len(fresh) == 0 {
+			if !recorded {
+				// 订阅时订阅源为空也要标记已记录，之后出现的条目才会通知
+				u.cfg.SeenRecorded = true
+				u.changed = true
+			}
+			Logger.Debug("  ✓ No new items for %s (subscription %d)", feedURL, u.id)
+			continue
+		}
+
+		// 每个订阅独立记录状态，新订阅首次只记录不通知
+		if recorded {
+			skip := 0
+			if len(fresh) > maxFeedNotifications {
+				Logger.Debug("  ℹ️ %d new items for %s, only sending the latest %d", len(fresh), feedURL, maxFeedNotifications)
+				skip = len(fresh) - maxFeedNotifications
+			}
+			// 只记录已写入发件箱的条目（以及不推送的旧条目），写入失败的条目下次检查重新处理
+			sent := skip
+			for _, item := range fresh[skip:] {
+				if !queueNotification(adminID, u, "feed", item.ID, buildMessage(item), nil) {
+					break
+				}
+				sent++
+			}
+			if sent > skip {
+				u.eventAt = time.Now()
+			}
+			fresh = fresh[:sent]
+			if len(fresh) == 0 {
+				continue
+			}
+		} else {
+			Logger.Debug("  ℹ️ Initial items recorded for %s: %d item(s)", feedURL, len(fresh))
+		}
+
+		// 新条目在前，保留最近 maxFeedItems 个
+		ids := make([]string, 0, len(fresh)+len(u.cfg.SeenItems))
+		for j := len(fresh) - 1; j >= 0; j-- {
+			ids = append(ids, fresh[j].ID)
+		}
+		ids = append(ids, u.cfg.SeenItems...)
+		if len(ids) > maxFeedItems {
+			ids = ids[:maxFeedItems]
+		}
+		u.cfg.SeenRecorded = true
+		u.cfg.SeenItems = ids
+		u.changed = true
+	}
+	return updates
+}
+
+// checkStarsGroup 同步新 Star：新出现的仓库自动添加 Release 订阅，并私聊通知管理员
+func checkStarsGroup(ctx context.Context, tg *telegramClient, adminID int64, job checkJob) []subUpdate {
+	updates := unchangedUpdates(job)
+	user := job.key.repo
+
+	Logger.Debug("  🔍 Checking stars for %q", user)
+	repos, err := githubAPI.StarredRepos(ctx, user)
+	if err != nil {
+		log.Printf("  ❌ Error listing stars for %q: %v", user, err)
+		return failedUpdates(updates, err)
+	}
+
+	for i := range updates {
+		u := &updates[i]
+		seen := make(map[string]bool, len(u.cfg.SeenItems))
+		for _, repo := range u.cfg.SeenItems {
+			seen[strings.ToLower(repo)] = true
+		}
+		var fresh []string
+		for _, repo := range repos {
+			if !seen[strings.ToLower(repo)] {
+				fresh = append(fresh, repo)
+			}
+		}
+
+		var failed []string
+		if len(fresh) > 0 && seenRecorded(&u.cfg, u.cfg.SeenItems) {
+			// 解析通知目标，新订阅与同步订阅推送到同一位置
+			var tgChat *chat
+			if u.cfg.ChannelID != 0 {
+				c, err := tg.getChat(strconv.FormatInt(u.cfg.ChannelID, 10))
+				if err != nil {
+					log.Printf("  ❌ Failed to get chat %d for star sync: %v", u.cfg.ChannelID, err)
+					u.err = err
+					continue
+				}
+				tgChat = c
+			}
+			opts := &addOptions{
+				showCommits:  u.cfg.ShowCommits,
+				assetFilter:  u.cfg.AssetFilter,
+				assetMaxSize: u.cfg.AssetMaxSize,
+				quietHours:   u.cfg.QuietHours,
+				quietMode:    u.cfg.QuietMode,
+			}
+			var added []string
+			added, _, failed, err = addReleaseSubscriptions(ctx, tg, tgChat, fresh, opts)
+			if err != nil {
+				log.Printf("  ❌ Failed to add starred repos for %q: %v", user, err)
+				u.err = err
+				continue
+			}
+			if len(added) > 0 || len(failed) > 0 {
+				log.Printf("⭐ New stars for %q: %d added, %d failed", user, len(added), len(failed))
+				// 订阅已经添加，通知发送失败时不再重试
+				if _, err := tg.sendMessage(adminID, Messages.StarsSynced(describeStarSource(user), added, failed), telegramParseModeMarkdown, true, "", 0); err != nil {
+					log.Printf("  ⚠️ Failed to notify admin about new stars for %q: %v", user, err)
+				}
+				u.eventAt = time.Now()
+			}
+		} else if len(fresh) == 0 && seenRecorded(&u.cfg, u.cfg.SeenItems) {
+			Logger.Debug("  ✓ No new stars for %q (subscription %d)", user, u.id)
+			continue
+		}
+
+		// 添加失败的仓库不记为已处理，下次同步时重试；连续失败 maxStarFailures 次（如仓库已删除）后放弃
+		retry := make(map[string]bool, len(failed))
+		failures := make(map[string]int, len(failed))
+		for _, repo := range failed {
+			key := strings.ToLower(repo)
+			if n := u.cfg.StarFailures[key] + 1; n < maxStarFailures {
+				retry[key] = true
+				failures[key] = n
+			} else {
+				log.Printf("  ⛔ Giving up on starred repo %s after %d failed attempts", repo, n)
+			}
+		}
+		if len(failures) == 0 {
+			failures = nil
+		}
+		seenRepos := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			if !retry[strings.ToLower(repo)] {
+				seenRepos = append(seenRepos, repo)
+			}
+		}
+		u.cfg.SeenItems = seenRepos
+		u.cfg.StarFailures = failures
+		u.cfg.SeenRecorded = true
+		u.changed = true
+	}
+	return updates
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// botCommand 命令表中的一项，同时驱动 handleMessage 的分发和 Telegram 命令菜单
+type botCommand struct {
+	name    string   // 不带斜杠的命令名
+	aliases []string // 别名，可以使用但不显示在菜单中
+	zh, en  string   // 菜单中的说明
+	group   bool     // 也在群组中向群管理员显示（否则只在管理员私聊中显示）
+	handle  func(tg *telegramClient, chatID int64, text string)
+}
+
+// botCommands 机器人支持的全部命令，按菜单显示顺序排列
+var botCommands = []botCommand{
+	{
+		name: "help", aliases: []string{"start"},
+		zh: "查看帮助", en: "Show help",
+		group: true,
+		handle: func(tg *telegramClient, chatID int64, text string) {
+			handleStart(tg, chatID)
+		},
+	},
+	{
+		name: "list",
+		zh:   "查看和管理订阅", en: "List and manage subscriptions",
+		group: true,
+		handle: func(tg *telegramClient, chatID int64, text string) {
+			handleList(tg, chatID)
+		},
+	},
+	{
+		name: "add",
+		zh:   "添加订阅", en: "Add a subscription",
+		group:  true,
+		handle: handleAdd,
+	},
+	{
+		name: "delete", aliases: []string{"del", "remove"},
+		zh: "删除订阅", en: "Delete a subscription",
+		handle: handleDelete,
+	},
+	{
+		name: "stars",
+		zh:   "从 Star 的仓库批量订阅", en: "Subscribe to starred repositories",
+		handle: handleStars,
+	},
+	{
+		name: "tokens",
+		zh:   "查看 GitHub Token 用量", en: "Show GitHub token usage",
+		handle: func(tg *telegramClient, chatID int64, text string) {
+			handleTokens(tg, chatID)
+		},
+	},
+}
+
+// findCommand 按命令名或别名（如 /list）查找命令
+func findCommand(cmd string) *botCommand {
+	for i := range botCommands {
+		c := &botCommands[i]
+		if "/"+c.name == cmd {
+			return c
+		}
+		for _, alias := range c.aliases {
+			if "/"+alias == cmd {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// registerCommands 注册命令菜单：中文为默认语言，另注册英文；
+// 全部命令只在管理员私聊中显示，群组命令只在订阅推送到的群组中向管理员本人显示
+func registerCommands(tg *telegramClient, adminID int64) error {
+	if err := setCommandMenu(tg, fmt.Sprintf(`{"type":"chat","chat_id":%d}`, adminID), false); err != nil {
+		return err
+	}
+	configs, err := loadConfigs()
+	if err != nil {
+		return err
+	}
+	seen := make(map[int64]bool)
+	groups := 0
+	for _, cfg := range configs {
+		if cfg.ChannelID == 0 || cfg.ChannelID == adminID || seen[cfg.ChannelID] {
+			continue
+		}
+		seen[cfg.ChannelID] = true
+		// 频道不支持 chat_member 范围，注册失败时忽略
+		if err := registerGroupCommands(tg, adminID, cfg.ChannelID); err != nil {
+			Logger.Debug("📋 Skipped command menu for chat %d: %v", cfg.ChannelID, err)
+			continue
+		}
+		groups++
+	}
+	log.Printf("📋 Registered %d command(s) in the menu (%d group(s))", len(botCommands), groups)
+	return nil
+}
+
+// registerGroupCommands 在群组中为管理员本人注册群组命令菜单
+func registerGroupCommands(tg *telegramClient, adminID, chatID int64) error {
+	return setCommandMenu(tg, fmt.Sprintf(`{"type":"chat_member","chat_id":%d,"user_id":%d}`, chatID, adminID), true)
+}
+
+// setCommandMenu 为指定范围注册中文和英文命令菜单，groupOnly 时只包含群组命令
+func setCommandMenu(tg *telegramClient, scope string, groupOnly bool) error {
+	for _, lang := range []string{"", "en"} {
+		var menu []telegramBotCommand
+		for _, c := range botCommands {
+			if groupOnly && !c.group {
+				continue
+			}
+			description := c.zh
+			if lang == "en" {
+				description = c.en
+			}
+			menu = append(menu, telegramBotCommand{Command: c.name, Description: description})
+		}
+		data, err := json.Marshal(menu)
+		if err != nil {
+			return err
+		}
+		if err := tg.setMyCommands(string(data), scope, lang); err != nil {
+			return fmt.Errorf("setMyCommands %s (%q): %w", scope, lang, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// commitGroup 按 Conventional Commits 类型分组的提交
+type commitGroup struct {
+	Label   string
+	Commits []string // 提交标题（第一行）
+}
+
+// commitTypes Conventional Commits 类型的展示顺序和名称，未列出的类型归入「其他」
+var commitTypes = []struct {
+	Type  string
+	Label string
+}{
+	{"feat", "✨ 新功能"},
+	{"fix", "🐛 修复"},
+	{"perf", "⚡ 性能"},
+	{"refactor", "♻️ 重构"},
+	{"docs", "📝 文档"},
+	{"deps", "📦 依赖"},
+}
+
+const otherCommitLabel = "🔧 其他"
+
+var conventionalCommitRegexp = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?!?:\s*(.+)$`)
+
+// releaseCommitRange 获取上一个 Release 到当前 Release 之间的提交并渲染为通知片段
+func releaseCommitRange(ctx context.Context, repo, prevTag, tag string) (string, error) {
+	cmp, err := githubAPI.CompareCommits(ctx, repo, prevTag, tag)
+	if err != nil {
+		return "", err
+	}
+	if len(cmp.Commits) == 0 {
+		return "", nil
+	}
+
+	// 提交过多时只列出最近的 maxRangeCommits 个
+	commits := cmp.Commits
+	if len(commits) > maxRangeCommits {
+		commits = commits[len(commits)-maxRangeCommits:]
+	}
+	total := cmp.TotalCommits
+	if total < len(cmp.Commits) {
+		total = len(cmp.Commits)
+	}
+	return Messages.ReleaseCommits(groupCommits(commits), total-len(commits), cmp.HTMLURL), nil
+}
+
+// groupCommits 按 Conventional Commits 类型分组，去掉类型前缀，组内保持提交顺序
+func groupCommits(commits []gitCommit) []commitGroup {
+	byType := make(map[string][]string)
+	var others []string
+	for _, c := range commits {
+		typ, title := classifyCommit(c.Commit.Message)
+		if commitTypeLabel(typ) == "" {
+			others = append(others, title)
+			continue
+		}
+		byType[typ] = append(byType[typ], title)
+	}
+
+	var groups []commitGroup
+	for _, t := range commitTypes {
+		if titles := byType[t.Type]; len(titles) > 0 {
+			groups = append(groups, commitGroup{Label: t.Label, Commits: titles})
+		}
+	}
+	if len(others) > 0 {
+		groups = append(groups, commitGroup{Label: otherCommitLabel, Commits: others})
+	}
+	return groups
+}
+
+// classifyCommit 解析提交信息第一行的类型，返回类型和去掉前缀后的标题
+func classifyCommit(message string) (string, string) {
+	title, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	title = strings.TrimSpace(title)
+	m := conventionalCommitRegexp.FindStringSubmatch(title)
+	if m == nil {
+		return "", title
+	}
+	typ := strings.ToLower(m[1])
+	// 依赖升级通常以 build(deps)/chore(deps) 提交
+	if (typ == "build" || typ == "chore") && strings.Contains(title, "(deps") {
+		typ = "deps"
+	}
+	return typ, m[2]
+}
+
+// commitTypeLabel 返回提交类型的展示名称，未知类型返回空字符串
+func commitTypeLabel(typ string) string {
+	for _, t := range commitTypes {
+		if t.Type == typ {
+			return t.Label
+		}
+	}
+	return ""
+}
@@ -12,6 +12,8 @@ import (
 
 // repoConfig 仓库配置
 type repoConfig struct {
+	ID             int64   `json:"id"`
+	Source         string  `json:"source,omitempty"` // 订阅来源，为空表示 GitHub 仓库
 	Repo           string  `json:"repo"`
 	RepoName       string  `json:"repo_name,omitempty"` // 不带所有者的仓库名
 	ChannelID      int64   `json:"channel_id,omitempty"`
@@ -22,15 +24,116 @@ type repoConfig struct {
 	Branch         string  `json:"branch,omitempty"`
 	LastReleaseID  *int64  `json:"last_release_id"`
 	LastCommitSHA  *string `json:"last_commit_sha"`
+	CheckInterval  string  `json:"check_interval,omitempty"` // 检查间隔，如 "10m"，为空使用默认值
+	Adaptive       bool    `json:"adaptive,omitempty"`       // 根据事件频率自动调整检查间隔
+	EventTimes     []int64 `json:"event_times,omitempty"`    // 最近检测到事件的时间（Unix 秒）
+	Created        int64   `json:"created,omitempty"`        // 订阅创建时间（Unix 秒），自适应模式下没有事件时据此逐渐降低检查频率
+	Paused         bool    `json:"paused,omitempty"`         // 暂停检查
+	AutoPaused     bool    `json:"auto_paused,omitempty"`    // 因机器人失去会话权限自动暂停，恢复权限后自动恢复
+
+	// 免打扰时段（按 TZ 环境变量的时区）
+	QuietHours string `json:"quiet_hours,omitempty"` // 如 "23:00-07:00"，为空表示不启用
+	QuietMode  string `json:"quiet_mode,omitempty"`  // silent、hold 或 drop，为空按 silent 处理
+
+	// Release 通知附带与上一个 Release 之间的提交记录
+	ShowCommits    bool    `json:"show_commits,omitempty"`
+	LastReleaseTag *string `json:"last_release_tag,omitempty"` // LastReleaseID 对应的 tag，用于比较提交范围
+
+	// Release 附件作为文档回复到通知下
+	AssetFilter  string `json:"asset_filter,omitempty"`   // 附件文件名 glob，多个用逗号分隔，为空表示不上传附件
+	AssetMaxSize int64  `json:"asset_max_size,omitempty"` // 单个附件大小上限（字节），为 0 时使用 Telegram 上限
+
+	// 容器镜像（Source 为 image 时使用，Branch 为监控 digest 的标签）
+	TagFilter   string   `json:"tag_filter,omitempty"`   // 标签正则，为空表示全部标签
+	WatchDigest bool     `json:"watch_digest,omitempty"` // 监控浮动标签（如 latest）的 digest 变化
+	KnownTags   []string `json:"known_tags,omitempty"`   // 已知标签，按版本从新到旧最多保留 maxKnownTags 个
+	LastDigest  *string  `json:"last_digest,omitempty"`
+
+	// 语言包（Source 为 go/npm/pypi/crates 时使用，Repo 为包名）
+	LastVersion *string `json:"last_version,omitempty"`
+
+	// RSS/Atom 订阅源（Source 为 feed 时使用，Repo 为订阅源 URL）
+	// Star 同步（Source 为 stars 时）复用此字段记录已处理的仓库
+	SeenItems []string `json:"seen_items,omitempty"` // 已通知条目的 GUID/ID
+	// Star 同步中添加失败的仓库（小写）及连续失败次数，达到 maxStarFailures 次后不再重试
+	StarFailures map[string]int `json:"star_failures,omitempty"`
+
+	// 已记录过 KnownTags/SeenItems 的初始列表（空列表保存后读回为 nil，不能据此判断是否为新订阅）
+	SeenRecorded bool `json:"seen_recorded,omitempty"`
 }
 
+// 订阅来源
+const (
+	sourceGitHub = ""
+	sourceImage  = "image"
+	sourceGo     = "go"
+	sourceNpm    = "npm"
+	sourcePyPI   = "pypi"
+	sourceCrates = "crates"
+	sourceFeed   = "feed"
+	sourceStars  = "stars" // 同步 GitHub 用户的新 Star，Repo 为用户名（为空表示 Token 所有者 Watch 的仓库）
+)
+
 var configMu sync.Mutex
 
 // loadConfigs 加载配置文件
 func loadConfigs() ([]repoConfig, error) {
 	configMu.Lock()
 	defer configMu.Unlock()
+	return readConfigsLocked()
+}
+
+// saveConfigs 保存配置文件
+func saveConfigs(configs []repoConfig) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+	return writeConfigsLocked(configs)
+}
+
+// updateConfigs 在同一把锁内读取、修改并保存配置
+// fn 返回修改后的配置以及是否需要保存
+func updateConfigs(fn func(configs []repoConfig) ([]repoConfig, bool)) error {
+	configMu.Lock()
+	defer configMu.Unlock()
 
+	configs, err := readConfigsLocked()
+	if err != nil {
+		return err
+	}
+	configs, changed := fn(configs)
+	if !changed {
+		return nil
+	}
+	return writeConfigsLocked(configs)
+}
+
+// updateConfig 按 ID 修改单个订阅，fn 返回 false 表示不保存；订阅不存在时 found 为 false
+func updateConfig(id int64, fn func(cfg *repoConfig) bool) (found bool, err error) {
+	err = updateConfigs(func(configs []repoConfig) ([]repoConfig, bool) {
+		for i := range configs {
+			if configs[i].ID == id {
+				found = true
+				return configs, fn(&configs[i])
+			}
+		}
+		return configs, false
+	})
+	return found, err
+}
+
+// nextConfigID 返回下一个可用的配置 ID
+func nextConfigID(configs []repoConfig) int64 {
+	var maxID int64
+	for _, cfg := range configs {
+		if cfg.ID > maxID {
+			maxID = cfg.ID
+		}
+	}
+	return maxID + 1
+}
+
+// readConfigsLocked 读取配置文件（调用方需持有 configMu）
+func readConfigsLocked() ([]repoConfig, error) {
 	_, err := os.Stat(configFile)
 	if errors.Is(err, os.ErrNotExist) {
 		Logger.Debug("📂 Config file not found, starting with empty config")
@@ -56,15 +159,19 @@ func loadConfigs() ([]repoConfig, error) {
 		log.Printf("❌ Failed to parse config file: %v", err)
 		return nil, fmt.Errorf("corrupt config file, please check %s: %w", configFile, err)
 	}
+
+	// 旧版本配置没有 ID，按顺序补齐（下次保存时写入文件）
+	for i := range configs {
+		if configs[i].ID == 0 {
+			configs[i].ID = nextConfigID(configs)
+		}
+	}
 	Logger.Debug("✅ Loaded %d config(s)", len(configs))
 	return configs, nil
 }
 
-// saveConfigs 保存配置文件
-func saveConfigs(configs []repoConfig) error {
-	configMu.Lock()
-	defer configMu.Unlock()
-
+// writeConfigsLocked 写入配置文件（调用方需持有 configMu）
+func writeConfigsLocked(configs []repoConfig) error {
 	if err := os.MkdirAll(filepath.Dir(configFile), 0o755); err != nil {
 		log.Printf("❌ Failed to create config directory: %v", err)
 		return err
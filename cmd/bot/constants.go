@@ -7,15 +7,157 @@ import (
 
 // 配置常量
 const (
-	configFile     = "/data/configs.json"
-	checkInterval  = 60 * time.Second
-	initialDelay   = 15 * time.Second
-	repoCheckDelay = 2 * time.Second
+	configFile            = "/data/configs.json"
+	checkInterval         = 60 * time.Second
+	initialDelay          = 15 * time.Second
+	githubRequestInterval = 750 * time.Millisecond // 全局 GitHub 请求间隔（约 4800 次/小时）
+	defaultCheckWorkers   = 4
+	schedulerTick         = 5 * time.Second // 调度器重新加载配置、派发到期订阅的周期
+	minCheckInterval      = 30 * time.Second
+)
+
+// 自适应轮询参数
+const (
+	adaptiveMinInterval   = minCheckInterval // 可以低于默认检查间隔，活跃仓库检查更频繁
+	adaptiveMaxInterval   = 6 * time.Hour
+	adaptivePollsPerEvent = 10 // 每个平均事件间隔内期望的检查次数
+	maxEventHistory       = 20 // 每个订阅保留的事件时间数量
+)
+
+// GitHub 客户端重试参数
+const (
+	githubMaxRetries  = 3
+	githubBaseBackoff = 500 * time.Millisecond
+	githubMaxBackoff  = 10 * time.Second
+	// 被限流（包括所有 Token 都被限流）时等待额度恢复的最长时间，更久则直接返回错误
+	githubMaxRetryWait = time.Minute
+	checkJobTimeout    = 2 * time.Minute // 单个分组检查任务的超时时间
+	handlerTimeout     = 30 * time.Second
+	// 永久性错误（如仓库已删除）后的检查间隔，避免反复请求
+	permanentErrorInterval = 6 * time.Hour
+)
+
+// 容器镜像参数
+const (
+	registryPageSize  = 1000 // 标签列表每页数量
+	maxTagsPerMessage = 10   // 单条通知最多列出的新标签数量
+	maxKnownTags      = 200  // 每个订阅按版本从新到旧保留的已知标签数量
+)
+
+// RSS/Atom 订阅源参数
+const (
+	maxFeedSize          = 5 << 20 // 订阅源最大字节数
+	maxFeedItems         = 200     // 每个订阅保留的已通知条目数量
+	maxFeedNotifications = 5       // 单次检查最多推送的新条目数量
+	maxFeedContentRunes  = 1500    // 条目正文展示和翻译的最大字符数
+)
+
+// CHANGELOG 回退参数
+const maxChangelogRunes = 3000 // 从 CHANGELOG 提取的更新日志最大字符数
+
+// Release 提交范围参数
+const maxRangeCommits = 20 // Release 通知中最多列出的提交数量
+
+// 依赖清单导入参数
+const (
+	maxManifestSize     = 1 << 20          // 依赖清单最大字节数
+	maxRegistryTextSize = 256 << 10        // 文本响应（如 go-import 页面、模块版本列表）最大字节数
+	importTimeout       = 10 * time.Minute // 解析整个依赖清单的超时时间
+)
+
+// Star 导入参数
+const (
+	maxStarredRepos   = 1000             // 最多读取的 Star/Watch 仓库数量
+	starsPerPage      = 8                // 选择键盘每页显示的仓库数量
+	starSessionTTL    = 30 * time.Minute // 选择会话的有效期
+	starsSyncInterval = "1h"             // 持续同步新 Star 的默认检查间隔
+	maxReportItems    = 30               // 导入和同步报告中最多列出的仓库数量
+	maxStarFailures   = 3                // 新 Star 的仓库连续添加失败多少次后不再重试
+)
+
+// 订阅管理界面参数
+const (
+	listPageSize    = 10               // /list 每页显示的订阅数量
+	pendingInputTTL = 10 * time.Minute // 等待回复（分支名、移动目标）的有效期
+)
+
+// Go 模块代理
+const goProxyURL = "https://proxy.golang.org"
+
+// GitHub App 参数
+const (
+	appInstallationsTTL   = 10 * time.Minute // 安装列表刷新周期
+	appTokenRefreshMargin = 5 * time.Minute  // 安装令牌在过期前多久刷新
+)
+
+// AI 翻译缓存参数
+const (
+	translationCacheTTL  = 6 * time.Hour
+	translationCacheSize = 500
 )
 
 // 正则表达式
 var repoRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+/[a-zA-Z0-9_.-]+$`)
 
+// Telegram 消息长度限制
+const (
+	maxMessageLength = 4096 // 单条消息最大字符数（UTF-16）
+	maxMessageParts  = 5    // 超长消息最多拆分的条数，超过时截断
+)
+
+// Telegram 发送队列参数（Telegram 限制：全局约 30 条/秒，单个私聊约 1 条/秒，群组和频道约 20 条/分钟）
+const (
+	telegramGlobalInterval  = 35 * time.Millisecond
+	telegramPrivateInterval = 1 * time.Second
+	telegramGroupInterval   = 3 * time.Second
+	telegramMaxRetries      = 5
+	telegramBaseBackoff     = 1 * time.Second
+	telegramMaxBackoff      = 30 * time.Second
+	telegramMaxRetryAfter   = 5 * time.Minute // 超过该等待时间的限流不再重试，交给下一次检查
+)
+
+// Release 附件参数
+const (
+	telegramMaxUploadSize = 50 << 20         // Telegram 机器人上传文件的大小上限
+	maxReleaseAssets      = 10               // 每个 Release 最多上传的附件数量（sendMediaGroup 上限）
+	assetUploadTimeout    = 10 * time.Minute // 单次下载并上传附件的超时时间
+)
+
+// 通知发件箱参数
+const (
+	outboxFile        = "/data/outbox.json"
+	outboxTick        = 30 * time.Second // 发件箱定时检查到期重试的周期
+	outboxBaseBackoff = 1 * time.Minute
+	outboxMaxBackoff  = 1 * time.Hour
+	outboxAlertAfter  = 5                  // 连续失败多少次后提醒管理员
+	outboxMaxAge      = 7 * 24 * time.Hour // 超过该时间仍未送达的通知放弃发送
+	outboxRetention   = 24 * time.Hour     // 已送达的通知保留多久（用于去重）
+)
+
+// MarkdownV2 解析失败时保存原始内容的位置
+const (
+	parseErrorDir      = "/data/parse_errors"
+	maxParseErrorDumps = 50
+)
+
+// telegramAllowedUpdates 接收的更新类型（长轮询和 Webhook 共用）
+const telegramAllowedUpdates = `["message","callback_query","my_chat_member","inline_query"]`
+
+// 内联查询参数
+const (
+	inlineCacheTTL      = 5 * time.Minute // 查询结果缓存时间，避免逐字输入时反复请求 GitHub
+	maxInlineBodyRunes  = 1000            // 结果卡片中 Release 正文和提交信息的最大字符数
+	inlineCacheMaxItems = 200
+	inlineDebounce      = 500 * time.Millisecond // 缓存未命中时等待输入停顿多久再查询
+)
+
+// Webhook 参数
+const (
+	defaultWebhookListen = ":8080" // Webhook HTTP 服务默认监听地址
+	maxWebhookBodySize   = 1 << 20 // 单条更新的最大字节数
+	webhookQueueSize     = 100     // 待处理更新队列长度
+)
+
 // Telegram 解析模式
 const (
 	telegramParseModeMarkdown = "MarkdownV2"
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// feedItem RSS/Atom 条目
+type feedItem struct {
+	ID      string
+	Title   string
+	Content string // 纯文本
+	Link    string
+}
+
+// feed 解析后的 RSS/Atom 订阅源
+type feed struct {
+	Title string
+	Items []feedItem // 与源中顺序一致（通常为从新到旧）
+}
+
+// rssItem RSS 2.0 / RSS 1.0 条目
+type rssItem struct {
+	About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"` // RSS 1.0 的条目标识
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	Description string `xml:"description"`
+	Content     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+}
+
+// rssDocument RSS 2.0
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rdfDocument RSS 1.0（RDF），条目与 channel 同级
+type rdfDocument struct {
+	Channel struct {
+		Title string `xml:"title"`
+	} `xml:"channel"`
+	Items []rssItem `xml:"item"`
+}
+
+// atomDocument Atom 1.0（GitLab、Gitea 等的 Release/Tag 订阅源）
+type atomDocument struct {
+	Title   string `xml:"title"`
+	Entries []struct {
+		ID    string `xml:"id"`
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Content string `xml:"content"`
+		Summary string `xml:"summary"`
+	} `xml:"entry"`
+}
+
+// fetchFeed 获取并解析 RSS 2.0 / Atom 订阅源
+func fetchFeed(ctx context.Context, feedURL string) (*feed, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "newrelease")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	Logger.Debug("📰 Feed: GET %s", feedURL)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, upstreamNetworkError("feed", feedURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, upstreamStatusError("feed", feedURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseFeed(data)
+}
+
+// parseFeed 根据根元素解析 RSS 或 Atom
+func parseFeed(data []byte) (*feed, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid feed: %w", err)
+	}
+
+	switch root.XMLName.Local {
+	case "rss":
+		var doc rssDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid RSS feed: %w", err)
+		}
+		return rssFeed(doc.Channel.Title, doc.Channel.Items), nil
+
+	case "RDF":
+		var doc rdfDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid RSS 1.0 feed: %w", err)
+		}
+		return rssFeed(doc.Channel.Title, doc.Items), nil
+
+	case "feed":
+		var doc atomDocument
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("invalid Atom feed: %w", err)
+		}
+		f := &feed{Title: strings.TrimSpace(doc.Title)}
+		for _, e := range doc.Entries {
+			link := ""
+			for _, l := range e.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			content := e.Content
+			if content == "" {
+				content = e.Summary
+			}
+			f.Items = append(f.Items, feedItem{
+				ID:      firstNonEmpty(e.ID, link, e.Title),
+				Title:   strings.TrimSpace(e.Title),
+				Content: htmlToText(content),
+				Link:    strings.TrimSpace(link),
+			})
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported feed format: <%s>", root.XMLName.Local)
+}
+
+// rssFeed 将 RSS 2.0 / RSS 1.0 条目转换为订阅源
+func rssFeed(title string, items []rssItem) *feed {
+	f := &feed{Title: strings.TrimSpace(title)}
+	for _, it := range items {
+		content := it.Content
+		if content == "" {
+			content = it.Description
+		}
+		f.Items = append(f.Items, feedItem{
+			ID:      firstNonEmpty(it.GUID, it.About, it.Link, it.Title),
+			Title:   strings.TrimSpace(it.Title),
+			Content: htmlToText(content),
+			Link:    strings.TrimSpace(it.Link),
+		})
+	}
+	return f
+}
+
+var (
+	htmlBreakRegexp = regexp.MustCompile(`(?i)<\s*(br|/p|/div|/li|/h[1-6])\s*/?>`)
+	htmlTagRegexp   = regexp.MustCompile(`<[^>]*>`)
+	blankLineRegexp = regexp.MustCompile(`\n\s*\n\s*\n+`)
+)
+
+// htmlToText 将 HTML 内容转换为纯文本（保留段落换行）
+func htmlToText(s string) string {
+	s = htmlBreakRegexp.ReplaceAllString(s, "\n")
+	s = htmlTagRegexp.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	s = strings.Join(lines, "\n")
+	s = blankLineRegexp.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncateRunes 按字符截断文本，超出时追加省略号
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max])) + "…"
+}
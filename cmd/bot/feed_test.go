@@ -0,0 +1,125 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *feed
+	}{
+		{
+			name: "rss",
+			data: `<?xml version="1.0"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title> Releases </title>
+    <item>
+      <title>v1.1.0</title>
+      <link>https://example.com/v1.1.0</link>
+      <guid>tag:v1.1.0</guid>
+      <description>short</description>
+      <content:encoded><![CDATA[<p>Fixed &amp; improved</p><p>Second</p>]]></content:encoded>
+    </item>
+    <item>
+      <title>v1.0.0</title>
+      <link>https://example.com/v1.0.0</link>
+      <description>&lt;b&gt;First&lt;/b&gt;</description>
+    </item>
+  </channel>
+</rss>`,
+			want: &feed{Title: "Releases", Items: []feedItem{
+				{ID: "tag:v1.1.0", Title: "v1.1.0", Content: "Fixed & improved\nSecond", Link: "https://example.com/v1.1.0"},
+				{ID: "https://example.com/v1.0.0", Title: "v1.0.0", Content: "First", Link: "https://example.com/v1.0.0"},
+			}},
+		},
+		{
+			name: "rdf",
+			data: `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+  <channel rdf:about="https://example.com/">
+    <title>News</title>
+  </channel>
+  <item rdf:about="https://example.com/2">
+    <title>Second</title>
+    <link>https://example.com/2</link>
+  </item>
+  <item rdf:about="urn:item:1">
+    <title>First</title>
+    <link>https://example.com/1</link>
+    <description>Hello</description>
+  </item>
+</rdf:RDF>`,
+			want: &feed{Title: "News", Items: []feedItem{
+				{ID: "https://example.com/2", Title: "Second", Link: "https://example.com/2"},
+				{ID: "urn:item:1", Title: "First", Content: "Hello", Link: "https://example.com/1"},
+			}},
+		},
+		{
+			name: "atom",
+			data: `<?xml version="1.0"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Tags</title>
+  <entry>
+    <id>tag:gitea,v2</id>
+    <title>v2</title>
+    <link rel="enclosure" href="https://example.com/v2.tar.gz"/>
+    <link href="https://example.com/v2"/>
+    <summary>Summary only</summary>
+  </entry>
+  <entry>
+    <title>v1</title>
+    <link rel="alternate" href="https://example.com/v1"/>
+    <content type="html">&lt;ul&gt;&lt;li&gt;a&lt;/li&gt;&lt;li&gt;b&lt;/li&gt;&lt;/ul&gt;</content>
+  </entry>
+</feed>`,
+			want: &feed{Title: "Tags", Items: []feedItem{
+				{ID: "tag:gitea,v2", Title: "v2", Content: "Summary only", Link: "https://example.com/v2"},
+				{ID: "https://example.com/v1", Title: "v1", Content: "a\nb", Link: "https://example.com/v1"},
+			}},
+		},
+		{
+			name: "empty rss",
+			data: `<rss><channel><title>Empty</title></channel></rss>`,
+			want: &feed{Title: "Empty"},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseFeed([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: parseFeed error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: parseFeed = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseFeedErrors(t *testing.T) {
+	for _, data := range []string{"", "not xml", "<html><body/></html>"} {
+		if _, err := parseFeed([]byte(data)); err == nil {
+			t.Errorf("parseFeed(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"<p>a</p><p>b</p>", "a\nb"},
+		{"line<br>next<br/>last", "line\nnext\nlast"},
+		{"a\n\n\n\nb", "a\n\nb"},
+		{"  &lt;tag&gt; &amp; &quot;x&quot;  ", `<tag> & "x"`},
+	}
+	for _, tt := range tests {
+		if got := htmlToText(tt.in); got != tt.want {
+			t.Errorf("htmlToText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
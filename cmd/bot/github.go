@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -13,11 +18,19 @@ import (
 
 // GitHub API 结构
 type gitHubRelease struct {
-	ID      int64  `json:"id"`
-	Name    string `json:"name"`
-	TagName string `json:"tag_name"`
-	Body    string `json:"body"`
-	HTMLURL string `json:"html_url"`
+	ID      int64         `json:"id"`
+	Name    string        `json:"name"`
+	TagName string        `json:"tag_name"`
+	Body    string        `json:"body"`
+	HTMLURL string        `json:"html_url"`
+	Assets  []gitHubAsset `json:"assets"`
+}
+
+// gitHubAsset Release 附件
+type gitHubAsset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
 }
 
 type gitCommit struct {
@@ -28,13 +41,25 @@ type gitCommit struct {
 	} `json:"commit"`
 }
 
+// gitHubComparison 两个 ref 之间的比较结果（commits 按时间从旧到新，最多 250 个）
+type gitHubComparison struct {
+	HTMLURL      string      `json:"html_url"`
+	TotalCommits int         `json:"total_commits"`
+	Commits      []gitCommit `json:"commits"`
+}
+
+type gitHubTag struct {
+	Name string `json:"name"`
+}
+
 type gitHubRepo struct {
 	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
 	DefaultBranch string `json:"default_branch"`
 }
 
-// githubToken 全局 GitHub Token（可选）
-var githubToken string
+// assetHTTPClient 下载 Release 附件使用的客户端，不设整体超时（由 ctx 控制），避免大文件下载被中断
+var assetHTTPClient = &http.Client{}
 
 // httpClient 全局 HTTP 客户端（复用连接）
 var httpClient = &http.Client{
@@ -46,17 +71,141 @@ var httpClient = &http.Client{
 	},
 }
 
+// githubLimiter 全局 GitHub 请求限速器（所有 worker 共享）
+var githubLimiter = newRateLimiter(githubRequestInterval)
+
 // setGitHubHeaders 设置 GitHub API 请求头
-func setGitHubHeaders(req *http.Request) {
-	req.Header.Set("Accept", "application/vnd.github+json")
+// 配置了 GitHub App 且已安装到仓库所有者时使用安装令牌，否则从 Token 池中选择剩余额度最多的 Token；
+// tried: 本次请求已尝试过的 Token。返回使用的池中 Token（未使用池时为空），池中没有可用 Token 时返回错误
+func setGitHubHeaders(req *http.Request, tried map[string]bool) (string, error) {
+	// 下载附件等请求会预先指定 Accept
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
 	req.Header.Set("User-Agent", "newrelease")
-	if githubToken != "" {
-		req.Header.Set("Authorization", "Bearer "+githubToken)
+	req.Header.Del("Authorization")
+
+	if githubAppAuth != nil {
+		if owner := ownerFromGitHubPath(req.URL.Path); owner != "" {
+			token, err := githubAppAuth.tokenFor(owner)
+			if err != nil {
+				log.Printf("⚠️ Failed to get installation token for %s: %v", owner, err)
+			} else if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+				return "", nil
+			}
+		}
+	}
+	if githubTokens == nil {
+		return "", nil
+	}
+	token, err := githubTokens.pick(tried)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return token, nil
+}
+
+// doGitHubRequest 发送 GitHub API 请求
+// 安装令牌被拒绝（401）时重新签发并重试一次；
+// Token 失效（401）或被限流（403/429）时自动换用池中的其他 Token 重试；
+// 所有 Token 都被限流时，额度在 githubMaxRetryWait 内恢复则等待，否则返回错误（不退回匿名请求）
+func doGitHubRequest(client *http.Client, req *http.Request) (*http.Response, error) {
+	tried := make(map[string]bool)
+	var last *http.Response
+	appRetried := false
+	for {
+		token, err := setGitHubHeaders(req, tried)
+		if err != nil {
+			// 已经换过 Token 时返回最后一次响应
+			if last != nil {
+				return last, nil
+			}
+			var unavailable *tokensUnavailableError
+			if errors.As(err, &unavailable) && canWaitUntil(req.Context(), unavailable.reset) {
+				log.Printf("⏳ All GitHub tokens are rate limited, waiting until %s", unavailable.reset.Format(time.RFC3339))
+				if err := sleepContext(req.Context(), time.Until(unavailable.reset)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			log.Printf("🚨 %v", err)
+			return nil, err
+		}
+		if last != nil {
+			last.Body.Close()
+			last = nil
+		}
+
+		if err := githubLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		checkRateLimit(resp)
+
+		if token == "" {
+			// 安装令牌被撤销或提前失效：丢弃缓存的令牌，重新签发后重试一次
+			if resp.StatusCode == http.StatusUnauthorized && githubAppAuth != nil && !appRetried &&
+				githubAppAuth.invalidateToken(ownerFromGitHubPath(req.URL.Path), bearerToken(req)) {
+				log.Printf("🚨 GitHub App installation token was rejected (401), minting a new one")
+				appRetried = true
+				resp.Body.Close()
+				continue
+			}
+			return resp, nil
+		}
+		tried[token] = true
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized:
+			log.Printf("🚨 GitHub token %s was rejected (401), rotating", maskToken(token))
+			githubTokens.markInvalid(token)
+		case isRateLimited(resp):
+			reset := rateLimitReset(resp)
+			log.Printf("⚠️ GitHub token %s is rate limited, rotating", maskToken(token))
+			githubTokens.markExhausted(token, reset)
+		default:
+			return resp, nil
+		}
+
+		// 没有其他可用 Token 时返回最后一次响应
+		if len(tried) >= len(githubTokens.tokens) {
+			return resp, nil
+		}
+		last = resp
+	}
+}
+
+// rateLimitReset 返回限流响应的额度恢复时间：优先 Retry-After，其次 X-RateLimit-Reset，都没有时按一分钟计
+func rateLimitReset(resp *http.Response) time.Time {
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+		return time.Now().Add(time.Duration(sec) * time.Second)
+	}
+	if sec, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(sec, 0)
+	}
+	return time.Now().Add(time.Minute)
+}
+
+// canWaitUntil 判断是否值得等到 t：不超过 githubMaxRetryWait，也不超过 ctx 的截止时间
+func canWaitUntil(ctx context.Context, t time.Time) bool {
+	if t.IsZero() || time.Until(t) > githubMaxRetryWait {
+		return false
 	}
+	deadline, ok := ctx.Deadline()
+	return !ok || t.Before(deadline)
 }
 
 // checkRateLimit 检查并记录 GitHub API Rate Limit
 func checkRateLimit(resp *http.Response) {
+	if githubTokens != nil && resp.Request != nil {
+		githubTokens.record(bearerToken(resp.Request), resp)
+	}
+
 	remaining := resp.Header.Get("X-RateLimit-Remaining")
 	limit := resp.Header.Get("X-RateLimit-Limit")
 
@@ -73,74 +222,195 @@ func checkRateLimit(resp *http.Response) {
 	}
 }
 
-// getLatestRelease 获取最新 Release
-func getLatestRelease(client *http.Client, repo string) (*gitHubRelease, error) {
-	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
-	Logger.Debug("🐙 GitHub API: GET %s", endpoint)
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	setGitHubHeaders(req)
+// gitHubError GitHub API 错误
+// Permanent 为 true 表示重试无意义（如 404 仓库不存在、451 因法律原因不可用）
+type gitHubError struct {
+	Endpoint  string
+	Status    int // 网络错误时为 0
+	Permanent bool
+	Err       error
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("❌ GitHub API error for %s: %v", repo, err)
-		return nil, err
+func (e *gitHubError) Error() string {
+	kind := "transient"
+	if e.Permanent {
+		kind = "permanent"
+	}
+	if e.Status != 0 {
+		return fmt.Sprintf("%s GitHub error: status %d for %s", kind, e.Status, e.Endpoint)
 	}
-	defer resp.Body.Close()
+	return fmt.Sprintf("%s GitHub error for %s: %v", kind, e.Endpoint, e.Err)
+}
 
-	checkRateLimit(resp)
+func (e *gitHubError) Unwrap() error { return e.Err }
 
-	if resp.StatusCode == http.StatusNotFound {
-		Logger.Debug("🔍 No releases found for %s", repo)
-		return nil, nil
-	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		log.Printf("❌ GitHub API returned status %d for %s", resp.StatusCode, repo)
-		return nil, fmt.Errorf("unexpected status %d from GitHub", resp.StatusCode)
-	}
+// isPermanentError 判断错误是否为永久性错误（GitHub 或其他上游）
+func isPermanentError(err error) bool {
+	var ghErr *gitHubError
+	var upErr *upstreamError
+	return (errors.As(err, &ghErr) && ghErr.Permanent) || (errors.As(err, &upErr) && upErr.Permanent)
+}
 
-	var release gitHubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		log.Printf("❌ Failed to decode release response for %s: %v", repo, err)
-		return nil, err
-	}
-	Logger.Debug("✔️ Found release for %s: %s (ID: %d)", repo, release.TagName, release.ID)
-	return &release, nil
+// isNotFound 判断错误是否为 404（GitHub 或其他上游）
+func isNotFound(err error) bool {
+	var ghErr *gitHubError
+	var upErr *upstreamError
+	return (errors.As(err, &ghErr) && ghErr.Status == http.StatusNotFound) ||
+		(errors.As(err, &upErr) && upErr.Status == http.StatusNotFound)
 }
 
-// getLatestCommit 获取最新 Commit
-func getLatestCommit(client *http.Client, repo, branch string) (*gitCommit, error) {
-	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/commits?sha=%s&per_page=1", repo, url.QueryEscape(branch))
-	Logger.Debug("🐙 GitHub API: GET %s", endpoint)
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
+// gitHubClient GitHub API 客户端
+// 幂等的 GET 请求在网络错误、5xx 和限流时按带抖动的指数退避重试
+type gitHubClient struct {
+	httpClient *http.Client
+	baseURL    string
+	maxRetries int
+}
+
+// githubAPI 全局 GitHub 客户端
+var githubAPI = newGitHubClient(httpClient)
+
+// newGitHubClient 创建 GitHub 客户端
+func newGitHubClient(client *http.Client) *gitHubClient {
+	return &gitHubClient{
+		httpClient: client,
+		baseURL:    "https://api.github.com",
+		maxRetries: githubMaxRetries,
 	}
-	setGitHubHeaders(req)
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("❌ GitHub API error for %s:%s: %v", repo, branch, err)
-		return nil, err
+// getJSON 请求 GitHub API 并解析 JSON 响应
+// path 为 baseURL 之后的路径（可带查询参数）
+func (c *gitHubClient) getJSON(ctx context.Context, path string, result interface{}) error {
+	endpoint := c.baseURL + path
+	var lastErr *gitHubError
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := backoffDelay(attempt, lastErr)
+			Logger.Debug("🔁 Retrying %s in %s (attempt %d/%d): %v", endpoint, delay, attempt, c.maxRetries, lastErr)
+			if err := sleepContext(ctx, delay); err != nil {
+				return err
+			}
+		}
+
+		Logger.Debug("🐙 GitHub API: GET %s", endpoint)
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := doGitHubRequest(c.httpClient, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var unavailable *tokensUnavailableError
+			if errors.As(err, &unavailable) {
+				// 额度短时间内不会恢复，重试无意义，等下次检查
+				return &gitHubError{Endpoint: endpoint, Err: err}
+			}
+			lastErr = &gitHubError{Endpoint: endpoint, Err: err}
+			continue
+		}
+
+		status := resp.StatusCode
+		if status >= 200 && status < 300 {
+			err := json.NewDecoder(resp.Body).Decode(result)
+			resp.Body.Close()
+			if err != nil {
+				return &gitHubError{Endpoint: endpoint, Status: status, Err: err}
+			}
+			return nil
+		}
+
+		rateLimited := isRateLimited(resp)
+		resp.Body.Close()
+
+		lastErr = &gitHubError{Endpoint: endpoint, Status: status, Permanent: isPermanentStatus(status)}
+		if !isTransientStatus(status) && !rateLimited {
+			// 401/403/422 等可能是 Token 临时失效，不重试但也不按永久性错误降低检查频率
+			return lastErr
+		}
+		if rateLimited {
+			// 等到额度恢复再重试；恢复时间太久时直接返回，等下次检查
+			wait := time.Until(rateLimitReset(resp))
+			if wait > githubMaxRetryWait {
+				Logger.Debug("⏳ %s is rate limited for %s, giving up", endpoint, wait.Round(time.Second))
+				lastErr.Err = retryAfterHint(wait)
+				return lastErr
+			}
+			lastErr.Err = retryAfterHint(wait)
+		}
 	}
-	defer resp.Body.Close()
 
-	checkRateLimit(resp)
+	log.Printf("❌ GitHub request failed after %d retries: %v", c.maxRetries, lastErr)
+	return lastErr
+}
+
+// isPermanentStatus 判断状态码是否表示资源不存在或不可用（404、451），重试无意义
+func isPermanentStatus(status int) bool {
+	return status == http.StatusNotFound || status == http.StatusUnavailableForLegalReasons
+}
+
+// isTransientStatus 判断状态码是否值得重试
+func isTransientStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests || status == http.StatusRequestTimeout
+}
+
+// retryAfterHint 携带服务端 Retry-After 提示的错误
+type retryAfterHint time.Duration
+
+func (h retryAfterHint) Error() string {
+	return fmt.Sprintf("retry after %s", time.Duration(h))
+}
+
+// backoffDelay 计算第 attempt 次重试前的等待时间（full jitter 指数退避）
+// 服务端给出 Retry-After 时优先使用
+func backoffDelay(attempt int, lastErr *gitHubError) time.Duration {
+	var hint retryAfterHint
+	if lastErr != nil && errors.As(lastErr.Err, &hint) {
+		if d := time.Duration(hint); d < githubMaxRetryWait {
+			return d
+		}
+		return githubMaxRetryWait
+	}
+	ceiling := githubBaseBackoff << uint(attempt-1)
+	if ceiling > githubMaxBackoff {
+		ceiling = githubMaxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
 
-	if resp.StatusCode == http.StatusNotFound {
-		Logger.Debug("🔍 No commits found for %s:%s", repo, branch)
+// LatestRelease 获取最新 Release，仓库没有 Release 时返回 nil
+// releases/latest 在仓库不存在时同样返回 404，此时确认仓库是否存在，已删除或改名时返回永久性错误
+func (c *gitHubClient) LatestRelease(ctx context.Context, repo string) (*gitHubRelease, error) {
+	var release gitHubRelease
+	err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/releases/latest", repo), &release)
+	if isNotFound(err) {
+		if _, err := c.RepoInfo(ctx, repo); err != nil {
+			return nil, err
+		}
+		Logger.Debug("🔍 No releases found for %s", repo)
 		return nil, nil
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		log.Printf("❌ GitHub API returned status %d for %s:%s", resp.StatusCode, repo, branch)
-		return nil, fmt.Errorf("unexpected status %d from GitHub", resp.StatusCode)
+	if err != nil {
+		return nil, err
 	}
+	Logger.Debug("✔️ Found release for %s: %s (ID: %d)", repo, release.TagName, release.ID)
+	return &release, nil
+}
 
+// LatestCommit 获取分支最新 Commit，仓库为空时返回 nil
+func (c *gitHubClient) LatestCommit(ctx context.Context, repo, branch string) (*gitCommit, error) {
 	var commits []gitCommit
-	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
-		log.Printf("❌ Failed to decode commit response for %s:%s: %v", repo, branch, err)
+	err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/commits?sha=%s&per_page=1", repo, url.QueryEscape(branch)), &commits)
+	var ghErr *gitHubError
+	if errors.As(err, &ghErr) && ghErr.Status == http.StatusConflict {
+		// 409: 空仓库
+		Logger.Debug("🔍 Repository %s is empty", repo)
+		return nil, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 	if len(commits) == 0 {
@@ -151,185 +421,132 @@ func getLatestCommit(client *http.Client, repo, branch string) (*gitCommit, erro
 	return &commits[0], nil
 }
 
-// getRepoInfo 获取仓库信息（名称、默认分支等）
-func getRepoInfo(client *http.Client, repo string) (*gitHubRepo, error) {
-	endpoint := fmt.Sprintf("https://api.github.com/repos/%s", repo)
-	Logger.Debug("🐙 GitHub API: GET %s (fetching repo info)", endpoint)
-	req, err := http.NewRequest("GET", endpoint, nil)
+// DownloadAsset 下载 Release 附件，返回的响应体由调用方边读边处理并关闭
+// 通过 API 地址下载以支持私有仓库；GitHub 重定向到存储地址时 Go 会去掉 Authorization 头
+func (c *gitHubClient) DownloadAsset(ctx context.Context, repo string, id int64) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/releases/assets/%d", c.baseURL, repo, id)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	setGitHubHeaders(req)
-
-	resp, err := client.Do(req)
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := doGitHubRequest(assetHTTPClient, req)
 	if err != nil {
-		log.Printf("❌ Failed to get repo info for %s: %v", repo, err)
-		return nil, err
+		return nil, &gitHubError{Endpoint: endpoint, Err: err}
 	}
-	defer resp.Body.Close()
-
-	checkRateLimit(resp)
-
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("❌ GitHub API returned status %d for repo %s", resp.StatusCode, repo)
-		return nil, fmt.Errorf("failed to get repo info: status %d", resp.StatusCode)
+		resp.Body.Close()
+		return nil, &gitHubError{Endpoint: endpoint, Status: resp.StatusCode, Permanent: isPermanentStatus(resp.StatusCode)}
+	}
+	return resp.Body, nil
+}
+
+// LatestTag 获取版本号最大的 tag（GitHub tags 接口按名称倒序，不一定是最新版本），仓库没有 tag 时返回 nil
+func (c *gitHubClient) LatestTag(ctx context.Context, repo string) (*gitHubTag, error) {
+	var tags []gitHubTag
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/tags?per_page=100", repo), &tags); err != nil {
+		return nil, err
 	}
+	if len(tags) == 0 {
+		Logger.Debug("🔍 No tags found for %s", repo)
+		return nil, nil
+	}
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	sortVersionsDesc(names)
+	for i := range tags {
+		if tags[i].Name == names[0] {
+			return &tags[i], nil
+		}
+	}
+	return &tags[0], nil
+}
 
+// RepoInfo 获取仓库信息（名称、默认分支等）
+func (c *gitHubClient) RepoInfo(ctx context.Context, repo string) (*gitHubRepo, error) {
 	var repoInfo gitHubRepo
-	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
-		log.Printf("❌ Failed to decode repo info for %s: %v", repo, err)
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s", repo), &repoInfo); err != nil {
 		return nil, err
 	}
 	Logger.Debug("✔️ Repo name: %s, Default branch: %s", repoInfo.Name, repoInfo.DefaultBranch)
 	return &repoInfo, nil
 }
 
-// scheduledChecker 定时检查器
-func scheduledChecker(tg *telegramClient, adminID int64) {
-	time.Sleep(initialDelay)
-
-	for {
-		Logger.Debug("Running scheduled check...")
-		configs, err := loadConfigs()
-		if err != nil {
-			log.Printf("Failed to load configs: %v", err)
-		} else if len(configs) == 0 {
-			Logger.Debug("No configurations found. Skipping check.")
-		} else {
-			configChanged := false
-
-			for i := range configs {
-				Logger.Debug("📦 [%d/%d] Checking %s...", i+1, len(configs), configs[i].Repo)
-				
-				// 检查 Release
-				if configs[i].MonitorRelease {
-					Logger.Debug("  🔍 Checking releases for %s", configs[i].Repo)
-					release, err := getLatestRelease(httpClient, configs[i].Repo)
-					if err != nil {
-						log.Printf("  ❌ Error fetching release for %s: %v", configs[i].Repo, err)
-					} else if release != nil {
-						if configs[i].LastReleaseID == nil || *configs[i].LastReleaseID != release.ID {
-							// 首次不发送通知
-							if configs[i].LastReleaseID != nil {
-								log.Printf("🆕 New release: %s@%s", configs[i].Repo, release.TagName)
-
-								// AI 翻译更新日志（如果有且非中文）
-								var releaseBody, releaseTranslation string
-								if body := strings.TrimSpace(release.Body); body != "" {
-									releaseBody = body
-									if translated, err := translateText(body); err != nil {
-										Logger.Debug("  ⚠️ AI translation failed for release body: %v", err)
-									} else if translated != "" {
-										releaseTranslation = translated
-									}
-								}
-
-								msg := Messages.NotifyRelease(configs[i].Repo, release.TagName, releaseBody, releaseTranslation, release.HTMLURL)
-								targetID := configs[i].ChannelID
-								if targetID == 0 {
-									targetID = adminID
-								}
-								threadID := configs[i].ThreadID
-								Logger.Debug("  📤 Sending release notification to %d (topic: %d)", targetID, threadID)
-								tg.sendMessage(targetID, msg, telegramParseModeMarkdown, true, "", threadID)
-							} else {
-								Logger.Debug("  ℹ️ Initial release recorded for %s: %s (ID: %d)", configs[i].Repo, release.TagName, release.ID)
-							}
-							latestID := release.ID
-							configs[i].LastReleaseID = &latestID
-							configChanged = true
-						} else {
-							Logger.Debug("  ✓ No new release for %s", configs[i].Repo)
-						}
-					} else {
-						Logger.Debug("  ℹ️ No releases found for %s", configs[i].Repo)
-					}
-				}
+// StarredRepos 列出用户 Star 的仓库（按 Star 时间从新到旧）；user 为空时列出 Token 所有者 Watch 的仓库
+// 自动翻页，最多返回 maxStarredRepos 个
+func (c *gitHubClient) StarredRepos(ctx context.Context, user string) ([]string, error) {
+	base := "/user/subscriptions"
+	if user != "" {
+		base = fmt.Sprintf("/users/%s/starred", url.PathEscape(user))
+	}
 
-				// 检查 Commit
-				if configs[i].MonitorCommit {
-					branch := configs[i].Branch
-					if branch == "" {
-						Logger.Debug("  🔍 Fetching repo info for %s", configs[i].Repo)
-						info, err := getRepoInfo(httpClient, configs[i].Repo)
-						if err != nil {
-							log.Printf("  ⚠️ Failed to get repo info for %s, using 'main': %v", configs[i].Repo, err)
-							branch = "main"
-						} else {
-							branch = info.DefaultBranch
-							if configs[i].RepoName == "" {
-								configs[i].RepoName = info.Name
-							}
-						}
-						// 缓存到配置，下次无需再请求 API
-						configs[i].Branch = branch
-						configChanged = true
-					}
-
-					Logger.Debug("  🔍 Checking commits for %s:%s", configs[i].Repo, branch)
-					commit, err := getLatestCommit(httpClient, configs[i].Repo, branch)
-					if err != nil {
-						log.Printf("  ❌ Error fetching commit for %s:%s: %v", configs[i].Repo, branch, err)
-					} else if commit != nil {
-						if configs[i].LastCommitSHA == nil || *configs[i].LastCommitSHA != commit.SHA {
-							// 首次不发送通知
-							if configs[i].LastCommitSHA != nil {
-								log.Printf("🆕 New commit: %s:%s@%.7s", configs[i].Repo, branch, commit.SHA)
-								message := strings.TrimSpace(commit.Commit.Message)
-								if message == "" {
-									message = commit.SHA
-								}
-
-								// AI 翻译
-								var translation string
-								if translated, err := translateText(message); err != nil {
-									Logger.Debug("  ⚠️ AI translation failed: %v", err)
-								} else if translated != "" {
-									translation = translated
-								}
-
-								repoName := configs[i].Repo
-								if parts := strings.Split(configs[i].Repo, "/"); len(parts) == 2 {
-									repoName = parts[1]
-								}
-								
-								// 使用 Messages 构建消息
-								msg := Messages.NotifyCommit(repoName, branch, message, translation, commit.HTMLURL)
-
-								targetID := configs[i].ChannelID
-								if targetID == 0 {
-									targetID = adminID
-								}
-								threadID := configs[i].ThreadID
-								Logger.Debug("  📤 Sending commit notification to %d (topic: %d)", targetID, threadID)
-								tg.sendMessage(targetID, msg, telegramParseModeMarkdown, true, "", threadID)
-							} else {
-								Logger.Debug("  ℹ️ Initial commit recorded for %s:%s: %.7s", configs[i].Repo, branch, commit.SHA)
-							}
-							latestSHA := commit.SHA
-							configs[i].LastCommitSHA = &latestSHA
-							configChanged = true
-						} else {
-							Logger.Debug("  ✓ No new commit for %s:%s", configs[i].Repo, branch)
-						}
-					} else {
-						Logger.Debug("  ℹ️ No commits found for %s:%s", configs[i].Repo, branch)
-					}
-				}
+	var repos []string
+	for page := 1; len(repos) < maxStarredRepos; page++ {
+		var batch []gitHubRepo
+		if err := c.getJSON(ctx, fmt.Sprintf("%s?per_page=100&page=%d", base, page), &batch); err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			repos = append(repos, r.FullName)
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+	if len(repos) > maxStarredRepos {
+		repos = repos[:maxStarredRepos]
+	}
+	Logger.Debug("✔️ Found %d starred/watched repo(s) for %q", len(repos), user)
+	return repos, nil
+}
 
-				time.Sleep(repoCheckDelay)
-			}
+// ReleaseByID 获取指定 ID 的 Release，已删除时返回 nil
+func (c *gitHubClient) ReleaseByID(ctx context.Context, repo string, id int64) (*gitHubRelease, error) {
+	var release gitHubRelease
+	err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/releases/%d", repo, id), &release)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
 
-			Logger.Debug("🎯 Check cycle complete for %d repositories", len(configs))
-			if configChanged {
-				Logger.Debug("🔄 Saving config updates...")
-				if err := saveConfigs(configs); err != nil {
-					log.Printf("❌ Failed to save configs: %v", err)
-				}
-			}
-		}
+// CompareCommits 比较两个 ref 之间的提交（base...head）
+func (c *gitHubClient) CompareCommits(ctx context.Context, repo, base, head string) (*gitHubComparison, error) {
+	var cmp gitHubComparison
+	path := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, url.PathEscape(base), url.PathEscape(head))
+	if err := c.getJSON(ctx, path, &cmp); err != nil {
+		return nil, err
+	}
+	Logger.Debug("✔️ %s: %d commit(s) between %s and %s", repo, cmp.TotalCommits, base, head)
+	return &cmp, nil
+}
 
-		Logger.Debug("Next check in %s", checkInterval)
-		time.Sleep(checkInterval)
+// FileContent 获取仓库在指定 ref 下的文件内容，文件不存在时返回空字符串
+func (c *gitHubClient) FileContent(ctx context.Context, repo, path, ref string) (string, error) {
+	var file struct {
+		Type     string `json:"type"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+	}
+	err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/contents/%s?ref=%s", repo, path, url.QueryEscape(ref)), &file)
+	if isNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if file.Type != "file" || file.Encoding != "base64" {
+		return "", nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("decode %s@%s:%s: %w", repo, ref, path, err)
 	}
+	Logger.Debug("✔️ Fetched %s@%s:%s (%d bytes)", repo, ref, path, len(data))
+	return string(data), nil
 }
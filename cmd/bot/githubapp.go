@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// githubApp GitHub App 认证：用私钥签发 JWT，换取各安装（installation）的访问令牌
+type githubApp struct {
+	appID               string
+	key                 *rsa.PrivateKey
+	defaultInstallation int64 // 找不到所有者对应安装时使用，为 0 表示不使用
+
+	// mu 只保护下面的字段，不在持有期间发起网络请求
+	mu            sync.Mutex
+	installations map[string]int64 // 所有者（小写）-> installation ID
+	listedAt      time.Time
+	tokens        map[int64]installationToken
+	minting       map[int64]*sync.Mutex // 每个安装一把锁，同一安装同时只签发一个令牌
+
+	listMu sync.Mutex // 同时只刷新一次安装列表
+}
+
+// installationToken 安装访问令牌
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type appInstallation struct {
+	ID      int64 `json:"id"`
+	Account struct {
+		Login string `json:"login"`
+	} `json:"account"`
+}
+
+// githubAppAuth 全局 GitHub App 认证（未配置时为 nil）
+var githubAppAuth *githubApp
+
+// loadGitHubApp 读取私钥文件并创建 GitHub App 认证
+func loadGitHubApp(appID, keyFile string, defaultInstallation int64) (*githubApp, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	// GitHub 下载的私钥为 PKCS#1，同时兼容 PKCS#8
+	var key *rsa.PrivateKey
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		key = k
+	} else {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key is not an RSA key")
+		}
+		key = rsaKey
+	}
+
+	return &githubApp{
+		appID:               appID,
+		key:                 key,
+		defaultInstallation: defaultInstallation,
+		installations:       make(map[string]int64),
+		tokens:              make(map[int64]installationToken),
+		minting:             make(map[int64]*sync.Mutex),
+	}, nil
+}
+
+// jwt 签发 App JWT（RS256，有效期 9 分钟，iat 提前 60 秒以容忍时钟偏差）
+func (a *githubApp) jwt() (string, error) {
+	now := time.Now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-60 * time.Second).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": a.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// appRequest 以 App 身份（JWT）请求 GitHub API
+func (a *githubApp) appRequest(method, endpoint string, result interface{}) error {
+	token, err := a.jwt()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "newrelease")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	Logger.Debug("🐙 GitHub App API: %s %s", method, endpoint)
+	if err := githubLimiter.Wait(context.Background()); err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from GitHub App API", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// refreshInstallations 刷新所有者到安装的映射（调用方需持有 a.listMu，不能持有 a.mu）
+func (a *githubApp) refreshInstallations() error {
+	installations := make(map[string]int64)
+	for page := 1; ; page++ {
+		var list []appInstallation
+		endpoint := "https://api.github.com/app/installations?per_page=100&page=" + strconv.Itoa(page)
+		if err := a.appRequest("GET", endpoint, &list); err != nil {
+			return err
+		}
+		for _, inst := range list {
+			installations[strings.ToLower(inst.Account.Login)] = inst.ID
+		}
+		if len(list) < 100 {
+			break
+		}
+	}
+	a.mu.Lock()
+	a.installations = installations
+	a.listedAt = time.Now()
+	a.mu.Unlock()
+	Logger.Debug("🔑 GitHub App installed on %d account(s)", len(installations))
+	return nil
+}
+
+// installationFor 返回所有者对应的安装 ID
+// 安装列表过期时由一个调用方刷新，其他调用方继续使用旧列表；首次使用时等待列表加载完成
+func (a *githubApp) installationFor(owner string) (int64, bool) {
+	a.mu.Lock()
+	listedAt := a.listedAt
+	a.mu.Unlock()
+
+	if time.Since(listedAt) > appInstallationsTTL {
+		var locked bool
+		if listedAt.IsZero() {
+			a.listMu.Lock()
+			locked = true
+		} else {
+			locked = a.listMu.TryLock()
+		}
+		if locked {
+			a.mu.Lock()
+			stale := time.Since(a.listedAt) > appInstallationsTTL
+			a.mu.Unlock()
+			if stale {
+				if err := a.refreshInstallations(); err != nil {
+					log.Printf("⚠️ Failed to list GitHub App installations: %v", err)
+					// 失败后同样等待一个周期再重试，避免每次请求都打到 App API
+					a.mu.Lock()
+					a.listedAt = time.Now()
+					a.mu.Unlock()
+				}
+			}
+			a.listMu.Unlock()
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if id, ok := a.installations[strings.ToLower(owner)]; ok {
+		return id, true
+	}
+	if a.defaultInstallation != 0 {
+		return a.defaultInstallation, true
+	}
+	return 0, false
+}
+
+// cachedToken 返回安装的未过期令牌
+func (a *githubApp) cachedToken(id int64) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if tok, ok := a.tokens[id]; ok && time.Until(tok.ExpiresAt) > appTokenRefreshMargin {
+		return tok.Token, true
+	}
+	return "", false
+}
+
+// tokenFor 返回可访问 owner 仓库的安装令牌，令牌在过期前自动刷新
+// 返回空字符串表示 App 未安装到该所有者；签发令牌时只阻塞同一安装的请求
+func (a *githubApp) tokenFor(owner string) (string, error) {
+	id, ok := a.installationFor(owner)
+	if !ok {
+		return "", nil
+	}
+	if token, ok := a.cachedToken(id); ok {
+		return token, nil
+	}
+
+	a.mu.Lock()
+	lock, ok := a.minting[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		a.minting[id] = lock
+	}
+	a.mu.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+	// 等锁期间其他请求可能已经签发了新令牌
+	if token, ok := a.cachedToken(id); ok {
+		return token, nil
+	}
+
+	var tok installationToken
+	endpoint := fmt.Sprintf("https://api.github.com/app/installations/%d/access_tokens", id)
+	if err := a.appRequest("POST", endpoint, &tok); err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	a.tokens[id] = tok
+	a.mu.Unlock()
+	Logger.Debug("🔑 Minted installation token for %s (installation %d, expires %s)", owner, id, tok.ExpiresAt.Format(time.RFC3339))
+	return tok.Token, nil
+}
+
+// invalidateToken 丢弃被 GitHub 拒绝（401）的安装令牌，下次请求时重新签发
+// 令牌已被其他请求换掉时返回 false
+func (a *githubApp) invalidateToken(owner, token string) bool {
+	id, ok := a.installationFor(owner)
+	if !ok {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if tok, ok := a.tokens[id]; !ok || tok.Token != token {
+		return false
+	}
+	delete(a.tokens, id)
+	return true
+}
+
+// ownerFromGitHubPath 从 API 路径中提取仓库所有者，如 /repos/{owner}/{repo}/...
+func ownerFromGitHubPath(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) >= 2 && (parts[0] == "repos" || parts[0] == "users" || parts[0] == "orgs") {
+		return parts[1]
+	}
+	return ""
+}
@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // handleMessage 处理文本消息
 func handleMessage(tg *telegramClient, msg *message, adminID int64) {
+	// 文件消息：依赖清单导入
+	if msg.Document != nil {
+		handleImport(tg, msg)
+		return
+	}
+
 	text := strings.TrimSpace(msg.Text)
 	if text == "" {
 		return
@@ -17,20 +28,36 @@ func handleMessage(tg *telegramClient, msg *message, adminID int64) {
 	cmd := parseCommand(text)
 	if cmd != "" {
 		Logger.Debug("🔧 Command: %s", cmd)
+		clearPendingInput(msg.Chat.ID)
+	} else if handlePendingInput(tg, msg.Chat.ID, text) {
+		return
+	}
+	if cmd == "" {
+		return
+	}
+	// 命令表见 commands.go，与命令菜单共用
+	if c := findCommand(cmd); c != nil {
+		c.handle(tg, msg.Chat.ID, text)
+	} else {
+		Logger.Debug("⚠️ Unknown command: %s", cmd)
 	}
-	switch cmd {
-	case "/start", "/help":
-		handleStart(tg, msg.Chat.ID)
-	case "/list":
-		handleList(tg, msg.Chat.ID)
-	case "/add":
-		handleAdd(tg, msg.Chat.ID, text)
-	case "/delete", "/del", "/remove":
-		handleDelete(tg, msg.Chat.ID, text)
+}
+
+// handleCallback 处理内联键盘按钮回调，回调数据格式为 <模块>:<参数>...
+func handleCallback(tg *telegramClient, cq *callbackQuery, adminID int64) {
+	if cq.Message == nil || cq.Message.Chat == nil {
+		tg.answerCallbackQuery(cq.ID, "")
+		return
+	}
+	parts := strings.Split(cq.Data, ":")
+	switch parts[0] {
+	case "stars":
+		handleStarsCallback(tg, cq, parts[1:])
+	case "list":
+		handleListCallback(tg, cq, parts[1:])
 	default:
-		if cmd != "" {
-			Logger.Debug("⚠️ Unknown command: %s", cmd)
-		}
+		Logger.Debug("⚠️ Unknown callback: %s", cq.Data)
+		tg.answerCallbackQuery(cq.ID, "")
 	}
 }
 
@@ -41,13 +68,113 @@ func handleStart(tg *telegramClient, chatID int64) {
 
 // handleList 处理 /list 命令
 func handleList(tg *telegramClient, chatID int64) {
-	msg, err := buildRepoListMessage()
+	msg, markup, err := buildRepoListMessage(0)
 	if err != nil {
 		log.Printf("Failed to build repo list: %v", err)
 		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
 		return
 	}
-	tg.sendMessage(chatID, msg, telegramParseModeMarkdown, false, "", 0)
+	tg.sendMessage(chatID, msg, telegramParseModeMarkdown, false, markup, 0)
+}
+
+// addOptions /add 命令的选项
+type addOptions struct {
+	monitorRelease bool
+	monitorCommit  bool
+	adaptive       bool
+	checkInterval  string
+	chatTarget     string // 可以是 @username 或群组 ID
+	tagFilter      string // 镜像标签正则
+	watchDigest    bool   // 监控浮动标签的 digest 变化
+	showCommits    bool   // Release 通知附带提交范围
+	assetFilter    string // Release 附件 glob
+	assetMaxSize   int64  // Release 附件大小上限（字节）
+	quietHours     string // 免打扰时段，如 23:00-07:00
+	quietMode      string // 免打扰方式
+}
+
+// parseAddOptions 解析 /add 命令中仓库之后的选项
+// 解析失败时返回要发送给用户的错误消息
+func parseAddOptions(args []string) (*addOptions, string) {
+	opts := &addOptions{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-r":
+			opts.monitorRelease = true
+		case "-c":
+			opts.monitorCommit = true
+		case "-a":
+			opts.adaptive = true
+		case "-d":
+			opts.watchDigest = true
+		case "-l":
+			opts.showCommits = true
+		case "-i":
+			// 自定义检查间隔，如 -i 10m
+			if i+1 >= len(args) {
+				return nil, Messages.ErrorInterval()
+			}
+			i++
+			d, err := time.ParseDuration(args[i])
+			if err != nil || d < minCheckInterval {
+				return nil, Messages.ErrorInterval()
+			}
+			opts.checkInterval = d.String()
+		case "-t":
+			// 镜像标签过滤，如 -t ^1\.\d+\.\d+$
+			if i+1 >= len(args) {
+				return nil, Messages.ErrorTagFilter()
+			}
+			i++
+			if _, err := regexp.Compile(args[i]); err != nil {
+				return nil, Messages.ErrorTagFilter()
+			}
+			opts.tagFilter = args[i]
+		case "-f":
+			// Release 附件过滤，如 -f *linux_amd64.tar.gz,*.deb
+			if i+1 >= len(args) {
+				return nil, Messages.ErrorAssetFilter()
+			}
+			i++
+			if !validAssetFilter(args[i]) {
+				return nil, Messages.ErrorAssetFilter()
+			}
+			opts.assetFilter = args[i]
+		case "-m":
+			// 附件大小上限，如 -m 20MB
+			if i+1 >= len(args) {
+				return nil, Messages.ErrorAssetSize()
+			}
+			i++
+			size, ok := parseSize(args[i])
+			if !ok || size > telegramMaxUploadSize {
+				return nil, Messages.ErrorAssetSize()
+			}
+			opts.assetMaxSize = size
+		case "-q":
+			// 免打扰时段，如 -q 23:00-07:00/hold
+			if i+1 >= len(args) {
+				return nil, Messages.ErrorQuietHours()
+			}
+			i++
+			window, mode, ok := parseQuietHours(args[i])
+			if !ok {
+				return nil, Messages.ErrorQuietHours()
+			}
+			opts.quietHours, opts.quietMode = window, mode
+		default:
+			// 支持 @username 格式
+			if strings.HasPrefix(args[i], "@") {
+				opts.chatTarget = args[i]
+			} else if strings.HasPrefix(args[i], "-") && len(args[i]) > 1 {
+				// 支持群组 ID 格式（负数，如 -1003786162788）
+				if _, err := strconv.ParseInt(args[i], 10, 64); err == nil {
+					opts.chatTarget = args[i]
+				}
+			}
+		}
+	}
+	return opts, ""
 }
 
 // handleAdd 处理 /add 命令
@@ -59,8 +186,32 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 		return
 	}
 
-	repo := args[1]
-	
+	opts, errMsg := parseAddOptions(args[2:])
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	// 语言包（如 npm:react、go:golang.org/x/net）走包注册表监控
+	if source, name, ok := parsePackageRef(args[1]); ok {
+		addPackage(tg, chatID, source, name, opts)
+		return
+	}
+	// RSS/Atom 订阅源（feed:https://... 或直接填写 URL）
+	if feedURL, ok := parseFeedRef(args[1]); ok {
+		addFeed(tg, chatID, feedURL, opts)
+		return
+	}
+	// 镜像仓库地址（如 docker.io/library/nginx）走容器镜像监控
+	if ref, ok := parseImageRef(args[1]); ok {
+		addImage(tg, chatID, ref, opts)
+		return
+	}
+	addGitHubRepo(tg, chatID, args[1], opts)
+}
+
+// addGitHubRepo 添加 GitHub 仓库监控
+func addGitHubRepo(tg *telegramClient, chatID int64, repo string, opts *addOptions) {
 	// 支持 owner/repo:branch 格式
 	branch := ""
 	if strings.Contains(repo, ":") {
@@ -74,40 +225,19 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 		return
 	}
 
-	monitorRelease := false
-	monitorCommit := false
-	chatTarget := "" // 可以是 @username 或群组 ID
-
-	// 解析参数
-	for i := 2; i < len(args); i++ {
-		switch args[i] {
-		case "-r":
-			monitorRelease = true
-		case "-c":
-			monitorCommit = true
-		default:
-			// 支持 @username 格式
-			if strings.HasPrefix(args[i], "@") {
-				chatTarget = args[i]
-			} else if strings.HasPrefix(args[i], "-") && len(args[i]) > 1 {
-				// 支持群组 ID 格式（负数，如 -1003786162788）
-				if _, err := strconv.ParseInt(args[i], 10, 64); err == nil {
-					chatTarget = args[i]
-				}
-			}
-		}
-	}
 	// 获取仓库信息（验证仓库存在并获取名称/默认分支）
-	repoInfo, err := getRepoInfo(httpClient, repo)
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	repoInfo, err := githubAPI.RepoInfo(ctx, repo)
 	if err != nil {
 		log.Printf("Failed to get repo info for %s: %v", repo, err)
 		tg.sendMessage(chatID, Messages.ErrorInvalidRepo(), telegramParseModeMarkdown, false, "", 0)
 		return
 	}
 	// 如果没有指定监控类型，默认两者都监控
-	if !monitorRelease && !monitorCommit {
-		monitorRelease = true
-		monitorCommit = true
+	if !opts.monitorRelease && !opts.monitorCommit {
+		opts.monitorRelease = true
+		opts.monitorCommit = true
 	}
 
 	// 如果 branch 仍然为空，使用 GitHub 返回的默认分支
@@ -115,46 +245,116 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 		branch = repoInfo.DefaultBranch
 	}
 
-	// 处理频道/群组
-	var channelID int64
-	var channelTitle string
-	var threadID int64 = 0
-	var tgChat *chat
-	
-	if chatTarget != "" {
-		c, err := tg.getChat(chatTarget)
-		if err != nil {
-			log.Printf("Failed to get chat %s: %v", chatTarget, err)
-			tg.sendMessage(chatID, Messages.ErrorChannelNotFound(), telegramParseModeMarkdown, false, "", 0)
-			return
-		}
-		
-		// 检查机器人是否为管理员
-		admins, err := tg.getChatAdministrators(c.ID)
-		if err != nil {
-			tg.sendMessage(chatID, Messages.ErrorBotNotAdmin(), telegramParseModeMarkdown, false, "", 0)
-			return
-		}
-		
-		isAdmin := false
-		for _, admin := range admins {
-			if admin.User.ID == tg.botID {
-				isAdmin = true
-				break
-			}
-		}
-		
-		if !isAdmin {
-			tg.sendMessage(chatID, Messages.ErrorBotNotAdmin(), telegramParseModeMarkdown, false, "", 0)
-			return
+	finishAdd(tg, chatID, repoConfig{
+		Repo:           repo,
+		RepoName:       repoInfo.Name,
+		MonitorRelease: opts.monitorRelease,
+		MonitorCommit:  opts.monitorCommit,
+		Branch:         branch,
+		ShowCommits:    opts.showCommits && opts.monitorRelease,
+		AssetFilter:    opts.assetFilter,
+		AssetMaxSize:   opts.assetMaxSize,
+	}, opts)
+}
+
+// addImage 添加容器镜像监控
+func addImage(tg *telegramClient, chatID int64, ref imageRef, opts *addOptions) {
+	if opts.watchDigest && ref.Tag == "" {
+		ref.Tag = "latest"
+	}
+
+	// 验证镜像存在且可访问
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	if _, err := registryAPI.ListTags(ctx, ref); err != nil {
+		log.Printf("Failed to list tags for %s: %v", ref, err)
+		tg.sendMessage(chatID, Messages.ErrorInvalidImage(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	cfg := repoConfig{
+		Source:    sourceImage,
+		Repo:      ref.String(),
+		RepoName:  ref.Name[strings.LastIndex(ref.Name, "/")+1:],
+		TagFilter: opts.tagFilter,
+	}
+	if opts.watchDigest {
+		cfg.WatchDigest = true
+		cfg.Branch = ref.Tag
+	}
+	finishAdd(tg, chatID, cfg, opts)
+}
+
+// addPackage 添加语言包版本监控
+func addPackage(tg *telegramClient, chatID int64, source, name string, opts *addOptions) {
+	registry := packageRegistries[source]
+
+	// 验证包存在
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	latest, err := registry.latest(ctx, name)
+	if err != nil || latest == nil {
+		log.Printf("Failed to fetch %s package %s: %v", registry.Label, name, err)
+		tg.sendMessage(chatID, Messages.ErrorInvalidPackage(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	finishAdd(tg, chatID, repoConfig{
+		Source:   source,
+		Repo:     name,
+		RepoName: name[strings.LastIndex(name, "/")+1:],
+	}, opts)
+}
+
+// parseFeedRef 解析订阅源地址，支持 feed: 前缀
+func parseFeedRef(ref string) (string, bool) {
+	ref = strings.TrimPrefix(ref, "feed:")
+	u, err := url.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return ref, true
+}
+
+// addFeed 添加 RSS/Atom 订阅源监控
+func addFeed(tg *telegramClient, chatID int64, feedURL string, opts *addOptions) {
+	// 验证订阅源可以解析，并用其标题作为话题名
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	f, err := fetchFeed(ctx, feedURL)
+	if err != nil {
+		log.Printf("Failed to fetch feed %s: %v", feedURL, err)
+		tg.sendMessage(chatID, Messages.ErrorInvalidFeed(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	name := f.Title
+	if name == "" {
+		if u, err := url.Parse(feedURL); err == nil {
+			name = u.Host
 		}
-		
-		channelID = c.ID
-		channelTitle = c.Title
-		tgChat = c
-	} else {
-		channelTitle = "私聊"
 	}
+	finishAdd(tg, chatID, repoConfig{
+		Source:   sourceFeed,
+		Repo:     feedURL,
+		RepoName: name,
+	}, opts)
+}
+
+// finishAdd 解析通知目标、检查重复、创建话题并保存新订阅
+func finishAdd(tg *telegramClient, chatID int64, newConfig repoConfig, opts *addOptions) {
+	newConfig.CheckInterval = opts.checkInterval
+	newConfig.Adaptive = opts.adaptive
+	newConfig.QuietHours, newConfig.QuietMode = opts.quietHours, opts.quietMode
+
+	// 处理频道/群组
+	tgChat, errMsg := resolveNotifyChat(tg, opts.chatTarget)
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+	setNotifyChat(&newConfig, tgChat)
+	channelID, channelTitle := newConfig.ChannelID, newConfig.ChannelTitle
 
 	// 加载现有配置
 	configs, err := loadConfigs()
@@ -165,46 +365,26 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 	}
 
 	// 检查重复（在创建话题之前检查）
-	// 如果由于没有 ThreadID 无法完全匹配，我们也应该检查该仓库是否已经在这个频道以相同的配置存在
-	for _, cfg := range configs {
-		if cfg.Repo == repo &&
-			cfg.ChannelID == channelID &&
-			cfg.MonitorRelease == monitorRelease &&
-			cfg.MonitorCommit == monitorCommit &&
-			cfg.Branch == branch {
+	for i := range configs {
+		if sameSubscription(&configs[i], &newConfig) {
 			tg.sendMessage(chatID, Messages.ErrorRepoExists(), telegramParseModeMarkdown, false, "", 0)
 			return
 		}
 	}
 
-	// 如果是开启话题功能的群组，自动创建话题
-	if tgChat != nil && tgChat.IsForum {
-		topicName := repoInfo.Name
-		topic, err := tg.createForumTopic(tgChat.ID, topicName)
-		if err != nil {
-			log.Printf("Failed to create forum topic for %s: %v", repo, err)
-			tg.sendMessage(chatID, Messages.ErrorCreateTopic(), telegramParseModeMarkdown, false, "", 0)
-			return
-		}
-		threadID = topic.MessageThreadID
-		log.Printf("📝 Created topic '%s' (thread_id: %d) in %s", topicName, threadID, channelTitle)
-	}
-
-	// 创建新配置
-	newConfig := repoConfig{
-		Repo:           repo,
-		RepoName:       repoInfo.Name,
-		ChannelID:      channelID,
-		ChannelTitle:   channelTitle,
-		ThreadID:       threadID,
-		MonitorRelease: monitorRelease,
-		MonitorCommit:  monitorCommit,
-		Branch:         branch,
+	// 如果是开启话题的群组，自动创建话题
+	if err := createSubscriptionTopic(tg, tgChat, &newConfig); err != nil {
+		tg.sendMessage(chatID, Messages.ErrorCreateTopic(), telegramParseModeMarkdown, false, "", 0)
+		return
 	}
+	threadID := newConfig.ThreadID
 
-	// 添加并保存
-	configs = append(configs, newConfig)
-	if err := saveConfigs(configs); err != nil {
+	// 添加并保存（基于最新配置追加，避免覆盖检查器写入的状态）
+	err = updateConfigs(func(current []repoConfig) ([]repoConfig, bool) {
+		newConfig.ID = nextConfigID(current)
+		return append(current, newConfig), true
+	})
+	if err != nil {
 		log.Printf("Failed to save configs: %v", err)
 		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
 		return
@@ -214,7 +394,7 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 	var notifyWay string
 	if threadID > 0 {
 		// 群组 + 话题
-		notifyWay = fmt.Sprintf("%s \\> %s", MDV2.Escape(channelTitle), MDV2.Escape(repoInfo.Name))
+		notifyWay = fmt.Sprintf("%s \\> %s", MDV2.Escape(channelTitle), MDV2.Escape(newConfig.RepoName))
 	} else if channelID != 0 {
 		// 频道/群组
 		notifyWay = MDV2.Escape(channelTitle)
@@ -223,33 +403,260 @@ func handleAdd(tg *telegramClient, chatID int64, text string) {
 		notifyWay = "私聊"
 	}
 
-	monitorTypeStr := ""
-	if monitorRelease && monitorCommit {
-		monitorTypeStr = "Release \\+ Commit"
-	} else if monitorRelease {
-		monitorTypeStr = "Release"
-	} else if monitorCommit {
-		monitorTypeStr = "Commit"
-	}
-
-	branchInfo := ""
-	if monitorCommit {
-		branchInfo = branch
-	}
-
 	successMsg := Messages.SuccessAdded(
-		MDV2.Escape(repo),
+		MDV2.EscapeCode(subscriptionName(&newConfig)),
 		notifyWay,
-		monitorTypeStr,
-		branchInfo,
+		describeMonitorType(&newConfig),
+		describeBranch(&newConfig, true),
+		describeInterval(&newConfig),
 	)
 
 	tg.sendMessage(chatID, successMsg, telegramParseModeMarkdown, false, "", 0)
 	if threadID > 0 {
-		log.Printf("➕ Added: %s -> %s (topic: %d)", repo, channelTitle, threadID)
+		log.Printf("➕ Added: %s -> %s (topic: %d)", newConfig.Repo, channelTitle, threadID)
 	} else {
-		log.Printf("➕ Added: %s", repo)
+		log.Printf("➕ Added: %s", newConfig.Repo)
+	}
+}
+
+// resolveNotifyChat 解析通知目标（@username 或群组 ID）并确认机器人是管理员
+// chatTarget 为空时返回 nil 表示私聊；失败时返回要发送给用户的错误消息
+func resolveNotifyChat(tg *telegramClient, chatTarget string) (*chat, string) {
+	if chatTarget == "" {
+		return nil, ""
+	}
+	c, err := tg.getChat(chatTarget)
+	if err != nil {
+		log.Printf("Failed to get chat %s: %v", chatTarget, err)
+		return nil, Messages.ErrorChannelNotFound()
+	}
+
+	// 检查机器人是否为管理员
+	admins, err := tg.getChatAdministrators(c.ID)
+	if err != nil {
+		return nil, Messages.ErrorBotNotAdmin()
+	}
+	for _, admin := range admins {
+		if admin.User.ID == tg.botID {
+			return c, ""
+		}
+	}
+	return nil, Messages.ErrorBotNotAdmin()
+}
+
+// setNotifyChat 将通知目标写入订阅配置，tgChat 为 nil 表示私聊
+func setNotifyChat(cfg *repoConfig, tgChat *chat) {
+	if tgChat == nil {
+		cfg.ChannelID = 0
+		cfg.ChannelTitle = "私聊"
+		return
+	}
+	cfg.ChannelID = tgChat.ID
+	cfg.ChannelTitle = tgChat.Title
+}
+
+// createSubscriptionTopic 在开启话题的群组中以订阅名称创建话题
+func createSubscriptionTopic(tg *telegramClient, tgChat *chat, cfg *repoConfig) error {
+	if tgChat == nil || !tgChat.IsForum {
+		return nil
+	}
+	topicName := cfg.RepoName
+	topic, err := tg.createForumTopic(tgChat.ID, topicName)
+	if err != nil {
+		log.Printf("Failed to create forum topic for %s: %v", cfg.Repo, err)
+		return err
+	}
+	cfg.ThreadID = topic.MessageThreadID
+	log.Printf("📝 Created topic '%s' (thread_id: %d) in %s", topicName, cfg.ThreadID, cfg.ChannelTitle)
+	return nil
+}
+
+// sameSubscription 判断两个订阅的配置是否相同（用于去重）
+func sameSubscription(a, b *repoConfig) bool {
+	return a.Source == b.Source &&
+		a.Repo == b.Repo &&
+		a.ChannelID == b.ChannelID &&
+		a.MonitorRelease == b.MonitorRelease &&
+		a.MonitorCommit == b.MonitorCommit &&
+		a.Branch == b.Branch &&
+		a.TagFilter == b.TagFilter &&
+		a.WatchDigest == b.WatchDigest
+}
+
+// handleImport 处理依赖清单文件：解析依赖后在后台解析源码仓库并批量添加 Release 订阅
+// 文件说明中可以填写与 /add 相同的选项，如 @group、-i 10m、-l
+func handleImport(tg *telegramClient, msg *message) {
+	chatID := msg.Chat.ID
+	doc := msg.Document
+	if _, ok := manifestParsers[path.Base(doc.FileName)]; !ok {
+		tg.sendMessage(chatID, Messages.ErrorManifest(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	args := strings.Fields(msg.Caption)
+	if len(args) > 0 && parseCommand(args[0]) == "/import" {
+		args = args[1:]
+	}
+	opts, errMsg := parseAddOptions(args)
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
 	}
+
+	data, err := tg.downloadFile(doc.FileID, maxManifestSize)
+	if err != nil {
+		log.Printf("Failed to download %s: %v", doc.FileName, err)
+		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+	deps, err := parseManifest(doc.FileName, data)
+	if err != nil || len(deps) == 0 {
+		log.Printf("Failed to parse %s: %v", doc.FileName, err)
+		tg.sendMessage(chatID, Messages.ErrorManifest(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	tgChat, errMsg := resolveNotifyChat(tg, opts.chatTarget)
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	tg.sendMessage(chatID, Messages.ImportStarted(doc.FileName, len(deps)), telegramParseModeMarkdown, false, "", 0)
+	log.Printf("📥 Importing %d dependencies from %s", len(deps), doc.FileName)
+	// 逐个请求注册表和 GitHub 耗时较长，放到后台执行，避免阻塞消息处理
+	go importDependencies(tg, chatID, tgChat, deps, opts)
+}
+
+// importDependencies 将依赖解析为 GitHub 仓库并批量添加 Release 订阅，完成后发送导入报告
+func importDependencies(tg *telegramClient, chatID int64, tgChat *chat, deps []manifestDependency, opts *addOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	// 解析源码仓库，多个依赖指向同一仓库时只订阅一次
+	type resolvedDep struct {
+		dep  manifestDependency
+		repo string
+	}
+	var resolved []resolvedDep
+	var unresolved []string
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		repo, err := resolveDependencyRepo(ctx, dep)
+		if err != nil {
+			Logger.Debug("  ⚠️ Failed to resolve %s: %v", dep, err)
+		}
+		if repo == "" {
+			unresolved = append(unresolved, dep.String())
+			continue
+		}
+		if key := strings.ToLower(repo); !seen[key] {
+			seen[key] = true
+			resolved = append(resolved, resolvedDep{dep: dep, repo: repo})
+		}
+	}
+
+	repos := make([]string, len(resolved))
+	depByRepo := make(map[string]string, len(resolved))
+	for i, r := range resolved {
+		repos[i] = r.repo
+		depByRepo[r.repo] = r.dep.String()
+	}
+	added, existing, failed, err := addReleaseSubscriptions(ctx, tg, tgChat, repos, opts)
+	if err != nil {
+		log.Printf("Failed to save configs: %v", err)
+		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+	for _, repo := range failed {
+		unresolved = append(unresolved, depByRepo[repo])
+	}
+
+	tg.sendMessage(chatID, Messages.ImportReport(describeNotifyChat(tgChat), added, existing, unresolved), telegramParseModeMarkdown, true, "", 0)
+	log.Printf("📥 Imported %d repositories (%d existing, %d unresolved)", len(added), existing, len(unresolved))
+}
+
+// addReleaseSubscriptions 批量添加 GitHub 仓库的 Release 订阅
+// 同一目标已监控 Release 的仓库计入 existing；仓库不存在或无法创建话题的计入 failed
+func addReleaseSubscriptions(ctx context.Context, tg *telegramClient, tgChat *chat, repos []string, opts *addOptions) (added []string, existing int, failed []string, err error) {
+	configs, err := loadConfigs()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var channelID int64
+	if tgChat != nil {
+		channelID = tgChat.ID
+	}
+	var newConfigs []repoConfig
+	for _, repo := range repos {
+		// 同一目标已监控该仓库 Release 时跳过（不区分分支）
+		duplicate := false
+		for i := range configs {
+			c := &configs[i]
+			if c.Source == sourceGitHub && strings.EqualFold(c.Repo, repo) && c.ChannelID == channelID && c.MonitorRelease {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			existing++
+			continue
+		}
+
+		// 验证仓库存在，并获取名称和默认分支
+		info, err := githubAPI.RepoInfo(ctx, repo)
+		if err != nil {
+			log.Printf("Failed to get repo info for %s: %v", repo, err)
+			failed = append(failed, repo)
+			continue
+		}
+		cfg := repoConfig{
+			Repo:           repo,
+			RepoName:       info.Name,
+			MonitorRelease: true,
+			Branch:         info.DefaultBranch,
+			ShowCommits:    opts.showCommits,
+			AssetFilter:    opts.assetFilter,
+			AssetMaxSize:   opts.assetMaxSize,
+			CheckInterval:  opts.checkInterval,
+			Adaptive:       opts.adaptive,
+			QuietHours:     opts.quietHours,
+			QuietMode:      opts.quietMode,
+		}
+		setNotifyChat(&cfg, tgChat)
+		if err := createSubscriptionTopic(tg, tgChat, &cfg); err != nil {
+			failed = append(failed, repo)
+			continue
+		}
+		newConfigs = append(newConfigs, cfg)
+	}
+
+	// 一次性追加保存
+	err = updateConfigs(func(current []repoConfig) ([]repoConfig, bool) {
+		for _, cfg := range newConfigs {
+			cfg.ID = nextConfigID(current)
+			current = append(current, cfg)
+		}
+		return current, len(newConfigs) > 0
+	})
+	if err != nil {
+		return nil, existing, failed, err
+	}
+
+	for _, cfg := range newConfigs {
+		added = append(added, cfg.Repo)
+		log.Printf("➕ Added: %s -> %s", cfg.Repo, cfg.ChannelTitle)
+	}
+	return added, existing, failed, nil
+}
+
+// describeNotifyChat 返回通知目标的展示名称（已转义）
+func describeNotifyChat(tgChat *chat) string {
+	if tgChat == nil {
+		return "私聊"
+	}
+	return MDV2.Escape(tgChat.Title)
 }
 
 // handleDelete 处理 /delete 命令
@@ -266,25 +673,25 @@ func handleDelete(tg *telegramClient, chatID int64, text string) {
 		return
 	}
 
-	configs, err := loadConfigs()
+	var deletedRepo string
+	total := 0
+	err = updateConfigs(func(configs []repoConfig) ([]repoConfig, bool) {
+		total = len(configs)
+		if index > len(configs) {
+			return configs, false
+		}
+		// 删除配置
+		deletedRepo = configs[index-1].Repo
+		return append(configs[:index-1], configs[index:]...), true
+	})
 	if err != nil {
-		log.Printf("Failed to load configs: %v", err)
+		log.Printf("Failed to delete config: %v", err)
 		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
 		return
 	}
 
-	if index > len(configs) {
-		tg.sendMessage(chatID, fmt.Sprintf("❌ 序号超出范围！当前只有 %d 个仓库。", len(configs)), "", false, "", 0)
-		return
-	}
-
-	// 删除配置
-	deletedRepo := configs[index-1].Repo
-	configs = append(configs[:index-1], configs[index:]...)
-
-	if err := saveConfigs(configs); err != nil {
-		log.Printf("Failed to save configs: %v", err)
-		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
+	if deletedRepo == "" {
+		tg.sendMessage(chatID, fmt.Sprintf("❌ 序号超出范围！当前只有 %d 个仓库。", total), "", false, "", 0)
 		return
 	}
 
@@ -292,3 +699,12 @@ func handleDelete(tg *telegramClient, chatID int64, text string) {
 	tg.sendMessage(chatID, successMsg, telegramParseModeMarkdown, false, "", 0)
 	log.Printf("🗑️ Deleted: %s", deletedRepo)
 }
+
+// handleTokens 处理 /tokens 命令，汇报各 GitHub Token 的额度使用情况
+func handleTokens(tg *telegramClient, chatID int64) {
+	if githubTokens == nil {
+		tg.sendMessage(chatID, Messages.TokensEmpty(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+	tg.sendMessage(chatID, Messages.TokenUsage(githubTokens.usage()), telegramParseModeMarkdown, false, "", 0)
+}
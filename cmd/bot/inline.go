@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// inlineCacheEntry 缓存的内联查询结果（JSON 数组）
+type inlineCacheEntry struct {
+	results string
+	expires time.Time
+}
+
+// inlineCall 正在进行的仓库查询，同一仓库的并发查询共用结果
+type inlineCall struct {
+	done    chan struct{}
+	results string
+	err     error
+}
+
+var inlineCache = struct {
+	sync.Mutex
+	entries map[string]inlineCacheEntry
+	calls   map[string]*inlineCall
+}{entries: make(map[string]inlineCacheEntry), calls: make(map[string]*inlineCall)}
+
+// inlineRun 用户正在处理的内联查询
+type inlineRun struct {
+	queryID string
+	cancel  context.CancelFunc
+}
+
+// inlineRuns 每个用户最近一次内联查询，新查询到达时取消旧查询（逐字输入时只查询停顿后的输入）
+var inlineRuns = struct {
+	sync.Mutex
+	byUser map[int64]inlineRun
+}{byUser: make(map[int64]inlineRun)}
+
+// handleInlineQuery 处理内联查询：输入 owner/repo（或仓库链接）时返回最新 Release、最新 tag 和最新提交三张结果卡片
+// 缓存未命中时先等待输入停顿 inlineDebounce，期间同一用户有新查询时放弃本次查询
+func handleInlineQuery(tg *telegramClient, iq *inlineQuery) {
+	repo := strings.TrimSpace(iq.Query)
+	if strings.Contains(repo, "github.com") {
+		repo = gitHubRepoFromURL(repo)
+	}
+	if !repoRegexp.MatchString(repo) {
+		tg.answerInlineQuery(iq.ID, "[]", 0)
+		return
+	}
+
+	key := strings.ToLower(repo)
+	inlineCache.Lock()
+	entry, ok := inlineCache.entries[key]
+	inlineCache.Unlock()
+	if !ok || time.Now().After(entry.expires) {
+		ctx, done := startInlineQuery(iq.From.ID, iq.ID)
+		defer done()
+		if err := sleepContext(ctx, inlineDebounce); err != nil {
+			Logger.Debug("🔎 Inline query %q superseded", iq.Query)
+			return
+		}
+		results, err := lookupInlineResults(ctx, key, repo)
+		if ctx.Err() != nil {
+			Logger.Debug("🔎 Inline query %q superseded", iq.Query)
+			return
+		}
+		if err != nil && !isNotFound(err) {
+			log.Printf("Failed to look up %s for inline query: %v", repo, err)
+			tg.answerInlineQuery(iq.ID, "[]", 0)
+			return
+		}
+		entry = inlineCacheEntry{results: results}
+	}
+
+	if err := tg.answerInlineQuery(iq.ID, entry.results, int(inlineCacheTTL.Seconds())); err != nil {
+		log.Printf("Failed to answer inline query: %v", err)
+	}
+}
+
+// startInlineQuery 为用户的新查询创建 ctx，并取消该用户之前尚未完成的查询；返回的函数在查询结束时调用
+func startInlineQuery(userID int64, queryID string) (context.Context, func()) {
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	inlineRuns.Lock()
+	if prev, ok := inlineRuns.byUser[userID]; ok {
+		prev.cancel()
+	}
+	inlineRuns.byUser[userID] = inlineRun{queryID: queryID, cancel: cancel}
+	inlineRuns.Unlock()
+
+	return ctx, func() {
+		cancel()
+		inlineRuns.Lock()
+		if run, ok := inlineRuns.byUser[userID]; ok && run.queryID == queryID {
+			delete(inlineRuns.byUser, userID)
+		}
+		inlineRuns.Unlock()
+	}
+}
+
+// lookupInlineResults 查询仓库并写入缓存；同一仓库已有查询进行中时等待其结果，不重复请求 GitHub
+func lookupInlineResults(ctx context.Context, key, repo string) (string, error) {
+	inlineCache.Lock()
+	if call, ok := inlineCache.calls[key]; ok {
+		inlineCache.Unlock()
+		select {
+		case <-call.done:
+			return call.results, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	call := &inlineCall{done: make(chan struct{})}
+	inlineCache.calls[key] = call
+	inlineCache.Unlock()
+
+	call.results, call.err = buildInlineResults(ctx, repo)
+	// 仓库不存在时也缓存空结果，逐字输入时不会重复请求
+	if call.err == nil || isNotFound(call.err) {
+		storeInlineResults(key, inlineCacheEntry{results: call.results, expires: time.Now().Add(inlineCacheTTL)})
+	}
+	inlineCache.Lock()
+	delete(inlineCache.calls, key)
+	inlineCache.Unlock()
+	close(call.done)
+	return call.results, call.err
+}
+
+// storeInlineResults 写入缓存，超过上限时先清理过期条目
+func storeInlineResults(key string, entry inlineCacheEntry) {
+	inlineCache.Lock()
+	defer inlineCache.Unlock()
+	if len(inlineCache.entries) >= inlineCacheMaxItems {
+		now := time.Now()
+		for k, e := range inlineCache.entries {
+			if now.After(e.expires) {
+				delete(inlineCache.entries, k)
+			}
+		}
+	}
+	if len(inlineCache.entries) < inlineCacheMaxItems {
+		inlineCache.entries[key] = entry
+	}
+}
+
+// buildInlineResults 查询仓库并用通知模板渲染结果卡片，仓库不存在时返回 "[]" 和 404 错误
+func buildInlineResults(ctx context.Context, repo string) (string, error) {
+	info, err := githubAPI.RepoInfo(ctx, repo)
+	if err != nil {
+		return "[]", err
+	}
+	if info.FullName != "" {
+		repo = info.FullName
+	}
+
+	results := []inlineQueryResult{}
+	add := func(id, title, description, url, text string) {
+		results = append(results, inlineQueryResult{
+			Type:        "article",
+			ID:          id,
+			Title:       title,
+			Description: description,
+			URL:         url,
+			InputMessageContent: inputTextMessageContent{
+				MessageText:           MDV2.Split(text, maxMessageLength)[0],
+				ParseMode:             telegramParseModeMarkdown,
+				DisableWebPagePreview: true,
+			},
+		})
+	}
+
+	release, err := githubAPI.LatestRelease(ctx, repo)
+	if err != nil {
+		return "[]", err
+	}
+	if release != nil {
+		body := truncateRunes(strings.TrimSpace(release.Body), maxInlineBodyRunes)
+		add("release", fmt.Sprintf("🚀 %s %s", repo, release.TagName), firstLine(release.Name, release.TagName), release.HTMLURL,
+			Messages.NotifyRelease(repo, release.TagName, body, "", "", release.HTMLURL))
+	}
+
+	tag, err := githubAPI.LatestTag(ctx, repo)
+	if err != nil {
+		return "[]", err
+	}
+	if tag != nil {
+		tagURL := fmt.Sprintf("https://github.com/%s/tree/%s", repo, tag.Name)
+		add("tag", fmt.Sprintf("🏷️ %s %s", repo, tag.Name), "最新 tag", tagURL,
+			Messages.NotifyRelease(repo, tag.Name, "", "", "", tagURL))
+	}
+
+	commit, err := githubAPI.LatestCommit(ctx, repo, info.DefaultBranch)
+	if err != nil {
+		return "[]", err
+	}
+	if commit != nil {
+		message := truncateRunes(strings.TrimSpace(commit.Commit.Message), maxInlineBodyRunes)
+		add("commit", fmt.Sprintf("🔨 %s@%.7s", repo, commit.SHA), firstLine(message, info.DefaultBranch), commit.HTMLURL,
+			Messages.NotifyCommit(info.Name, info.DefaultBranch, message, "", commit.HTMLURL))
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return "[]", err
+	}
+	return string(data), nil
+}
+
+// firstLine 返回文本的第一行，为空时返回 fallback
+func firstLine(text, fallback string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if line == "" {
+		return fallback
+	}
+	return line
+}
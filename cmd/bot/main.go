@@ -20,12 +20,43 @@ func main() {
 		log.Fatal("FATAL: ADMIN_ID is not a valid integer or is not set in the environment.")
 	}
 
-	// 读取 GitHub Token（可选）
-	githubToken = strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
-	if githubToken != "" {
-		Logger.Debug("GitHub Token configured")
+	// 读取 GitHub Token（可选，多个 Token 用逗号分隔，额度合并使用）
+	tokens := strings.Split(os.Getenv("GITHUB_TOKEN"), ",")
+	tokens = append(tokens, strings.Split(os.Getenv("GITHUB_TOKENS"), ",")...)
+	githubTokens = newGitHubTokenPool(tokens)
+	if githubTokens != nil {
+		Logger.Debug("GitHub Token configured (%d token(s))", len(githubTokens.tokens))
 	}
 
+	// 读取 GitHub App 配置（可选）
+	appID := strings.TrimSpace(os.Getenv("GITHUB_APP_ID"))
+	appKeyFile := strings.TrimSpace(os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE"))
+	if appID != "" && appKeyFile != "" {
+		var defaultInstallation int64
+		if raw := strings.TrimSpace(os.Getenv("GITHUB_APP_INSTALLATION_ID")); raw != "" {
+			defaultInstallation, err = strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				log.Fatal("FATAL: GITHUB_APP_INSTALLATION_ID is not a valid integer.")
+			}
+		}
+		githubAppAuth, err = loadGitHubApp(appID, appKeyFile, defaultInstallation)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load GitHub App: %v", err)
+		}
+		Logger.Debug("GitHub App configured (App ID: %s)", appID)
+	}
+
+	// 读取并发检查 worker 数量（可选）
+	workers := defaultCheckWorkers
+	if raw := strings.TrimSpace(os.Getenv("CHECK_WORKERS")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			log.Fatal("FATAL: CHECK_WORKERS must be a positive integer.")
+		}
+		workers = n
+	}
+	Logger.Debug("Check workers: %d", workers)
+
 	// 读取 AI 配置（可选）
 	aiKey := os.Getenv("AI_API_KEY")
 	aiBase := os.Getenv("AI_BASE_URL")
@@ -41,10 +72,41 @@ func main() {
 		log.Fatalf("Failed to fetch bot info: %v", err)
 	}
 	tg.botID = me.ID
+	tg.onMigrate = func(oldID, newID int64) {
+		migrateChat(tg, adminID, oldID, newID)
+	}
 
 	log.Printf("Bot starting... Authorized Admin User ID is %d", adminID)
 
-	go scheduledChecker(tg, adminID)
+	if err := registerCommands(tg, adminID); err != nil {
+		log.Printf("⚠️ Failed to register command menu: %v", err)
+	}
+
+	go runOutbox(tg, adminID)
+	go scheduledChecker(tg, adminID, workers)
+
+	// 配置了公网地址时使用 Webhook 接收更新，否则使用长轮询
+	webhookURL := strings.TrimSpace(os.Getenv("WEBHOOK_URL"))
+	if webhookURL != "" {
+		listen := strings.TrimSpace(os.Getenv("WEBHOOK_LISTEN"))
+		if listen == "" {
+			listen = defaultWebhookListen
+		}
+		if err := runWebhook(tg, adminID, webhookURL, listen, strings.TrimSpace(os.Getenv("WEBHOOK_SECRET"))); err != nil {
+			log.Fatalf("FATAL: Webhook server failed: %v", err)
+		}
+		return
+	}
+	runPolling(tg, adminID)
+}
+
+// runPolling 通过 getUpdates 长轮询接收更新
+func runPolling(tg *telegramClient, adminID int64) {
+	// 之前设置过 Webhook 时 getUpdates 会被拒绝，先删除
+	if err := tg.deleteWebhook(); err != nil {
+		log.Printf("⚠️ Failed to delete webhook: %v", err)
+	}
+	log.Printf("📡 Receiving updates via long polling")
 
 	offset := 0
 	for {
@@ -59,20 +121,62 @@ func main() {
 			if upd.UpdateID >= offset {
 				offset = upd.UpdateID + 1
 			}
+			handleUpdate(tg, upd, adminID)
+		}
+	}
+}
 
-			// 只处理文本消息
-			if upd.Message == nil || upd.Message.From == nil || upd.Message.Chat == nil {
-				continue
-			}
+// handleUpdate 分发一条更新（长轮询和 Webhook 共用），只响应管理员
+func handleUpdate(tg *telegramClient, upd update, adminID int64) {
+	// 内联查询，只为管理员返回结果
+	if iq := upd.InlineQuery; iq != nil {
+		if iq.From == nil || iq.From.ID != adminID {
+			tg.answerInlineQuery(iq.ID, "[]", 0)
+			return
+		}
+		Logger.Debug("🔎 Inline query: %q", iq.Query)
+		// 查询需要多次请求 GitHub，放到单独的协程中，不阻塞后续更新的处理
+		go handleInlineQuery(tg, iq)
+		return
+	}
 
-			fromID := upd.Message.From.ID
-			if fromID != adminID {
-				log.Printf("Unauthorized access attempt by user %d (%s %s)", fromID, upd.Message.From.FirstName, upd.Message.From.LastName)
-				continue
+	// 内联键盘按钮回调
+	if cq := upd.CallbackQuery; cq != nil {
+		if cq.From == nil || cq.From.ID != adminID {
+			if cq.From != nil {
+				log.Printf("Unauthorized callback by user %d (%s %s)", cq.From.ID, cq.From.FirstName, cq.From.LastName)
 			}
-
-			Logger.Debug("📩 Received: %q", upd.Message.Text)
-			handleMessage(tg, upd.Message, adminID)
+			tg.answerCallbackQuery(cq.ID, "")
+			return
 		}
+		Logger.Debug("🔘 Callback: %q", cq.Data)
+		handleCallback(tg, cq, adminID)
+		return
 	}
+
+	// 机器人被移出会话或权限变化，任何人操作都需要处理
+	if upd.MyChatMember != nil {
+		handleMyChatMember(tg, upd.MyChatMember, adminID)
+		return
+	}
+
+	// 只处理用户消息（文本或依赖清单文件）
+	if upd.Message == nil || upd.Message.From == nil || upd.Message.Chat == nil {
+		return
+	}
+
+	// 群组升级为超级群组，任何成员触发都需要处理
+	if newID := upd.Message.MigrateToChatID; newID != 0 {
+		migrateChat(tg, adminID, upd.Message.Chat.ID, newID)
+		return
+	}
+
+	fromID := upd.Message.From.ID
+	if fromID != adminID {
+		log.Printf("Unauthorized access attempt by user %d (%s %s)", fromID, upd.Message.From.FirstName, upd.Message.From.LastName)
+		return
+	}
+
+	Logger.Debug("📩 Received: %q", upd.Message.Text)
+	handleMessage(tg, upd.Message, adminID)
 }
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pendingInput 等待管理员回复的输入（修改分支、移动订阅、设置免打扰）
+type pendingInput struct {
+	kind      string // branch、move 或 quiet
+	id        int64  // 订阅 ID
+	messageID int    // 管理界面消息，输入完成后原地更新
+	page      int
+	expires   time.Time
+}
+
+var pendingInputs = struct {
+	sync.Mutex
+	byChat map[int64]pendingInput
+}{byChat: make(map[int64]pendingInput)}
+
+// handleListCallback 处理 /list 管理界面的按钮
+// 回调数据：pg:<页> 翻页，v:<ID>:<页> 查看，p 暂停/恢复，d 删除（dy 确认），e 切换监控类型，b 修改分支，m 移动，q 免打扰
+func handleListCallback(tg *telegramClient, cq *callbackQuery, args []string) {
+	chatID, messageID := cq.Message.Chat.ID, cq.Message.MessageID
+	if len(args) < 2 {
+		tg.answerCallbackQuery(cq.ID, "")
+		return
+	}
+	action := args[0]
+	if action == "pg" {
+		page, _ := strconv.Atoi(args[1])
+		tg.answerCallbackQuery(cq.ID, "")
+		showListPage(tg, chatID, messageID, page)
+		return
+	}
+
+	id, _ := strconv.ParseInt(args[1], 10, 64)
+	page := 0
+	if len(args) > 2 {
+		page, _ = strconv.Atoi(args[2])
+	}
+	clearPendingInput(chatID)
+
+	switch action {
+	case "v":
+		tg.answerCallbackQuery(cq.ID, "")
+
+	case "p":
+		var paused bool
+		found, err := updateConfig(id, func(cfg *repoConfig) bool {
+			cfg.Paused = !cfg.Paused
+			cfg.AutoPaused = false
+			paused = cfg.Paused
+			return true
+		})
+		if !answerConfigUpdate(tg, cq, found, err) {
+			break
+		}
+		if paused {
+			tg.answerCallbackQuery(cq.ID, Messages.CallbackPaused())
+			log.Printf("⏸️ Paused subscription %d", id)
+		} else {
+			tg.answerCallbackQuery(cq.ID, Messages.CallbackResumed())
+			log.Printf("▶️ Resumed subscription %d", id)
+		}
+
+	case "d":
+		tg.answerCallbackQuery(cq.ID, "")
+		cfg, _, err := findConfig(id)
+		if err != nil || cfg == nil {
+			showListPage(tg, chatID, messageID, page)
+			return
+		}
+		markup := inlineKeyboard([][]inlineKeyboardButton{{
+			{Text: "✅ 确认删除", CallbackData: fmt.Sprintf("list:dy:%d:%d", id, page)},
+			{Text: "取消", CallbackData: fmt.Sprintf("list:v:%d:%d", id, page)},
+		}})
+		tg.editMessageText(chatID, messageID, Messages.ManageConfirmDelete(MDV2.EscapeCode(subscriptionName(cfg))), telegramParseModeMarkdown, markup)
+		return
+
+	case "dy":
+		var deleted string
+		err := updateConfigs(func(configs []repoConfig) ([]repoConfig, bool) {
+			for i := range configs {
+				if configs[i].ID == id {
+					deleted = subscriptionName(&configs[i])
+					return append(configs[:i], configs[i+1:]...), true
+				}
+			}
+			return configs, false
+		})
+		if err != nil {
+			log.Printf("Failed to delete config: %v", err)
+			tg.answerCallbackQuery(cq.ID, Messages.CallbackFailed())
+			return
+		}
+		tg.answerCallbackQuery(cq.ID, "")
+		if deleted != "" {
+			log.Printf("🗑️ Deleted: %s", deleted)
+		}
+		showListPage(tg, chatID, messageID, page)
+		return
+
+	case "e":
+		found, err := updateConfig(id, func(cfg *repoConfig) bool {
+			if cfg.Source != sourceGitHub {
+				return false
+			}
+			// Release + Commit -> 仅 Release -> 仅 Commit -> Release + Commit
+			switch {
+			case cfg.MonitorRelease && cfg.MonitorCommit:
+				cfg.MonitorCommit = false
+			case cfg.MonitorRelease:
+				cfg.MonitorRelease, cfg.MonitorCommit = false, true
+			default:
+				cfg.MonitorRelease, cfg.MonitorCommit = true, true
+			}
+			return true
+		})
+		if answerConfigUpdate(tg, cq, found, err) {
+			tg.answerCallbackQuery(cq.ID, "")
+		}
+
+	case "b", "m", "q":
+		tg.answerCallbackQuery(cq.ID, "")
+		cfg, _, err := findConfig(id)
+		if err != nil || cfg == nil {
+			showListPage(tg, chatID, messageID, page)
+			return
+		}
+		kind, prompt := "branch", Messages.ManagePromptBranch(MDV2.EscapeCode(subscriptionName(cfg)))
+		switch action {
+		case "m":
+			kind, prompt = "move", Messages.ManagePromptMove(MDV2.EscapeCode(subscriptionName(cfg)))
+		case "q":
+			kind, prompt = "quiet", Messages.ManagePromptQuiet(MDV2.EscapeCode(subscriptionName(cfg)))
+		}
+		pendingInputs.Lock()
+		pendingInputs.byChat[chatID] = pendingInput{kind: kind, id: id, messageID: messageID, page: page, expires: time.Now().Add(pendingInputTTL)}
+		pendingInputs.Unlock()
+		markup := inlineKeyboard([][]inlineKeyboardButton{{
+			{Text: "取消", CallbackData: fmt.Sprintf("list:v:%d:%d", id, page)},
+		}})
+		tg.editMessageText(chatID, messageID, prompt, telegramParseModeMarkdown, markup)
+		return
+
+	default:
+		tg.answerCallbackQuery(cq.ID, "")
+		return
+	}
+	showSubscription(tg, chatID, messageID, id, page)
+}
+
+// answerConfigUpdate 处理按 ID 修改订阅的结果，失败时响应回调并返回 false
+func answerConfigUpdate(tg *telegramClient, cq *callbackQuery, found bool, err error) bool {
+	if err != nil {
+		log.Printf("Failed to update config: %v", err)
+		tg.answerCallbackQuery(cq.ID, Messages.CallbackFailed())
+		return false
+	}
+	if !found {
+		tg.answerCallbackQuery(cq.ID, Messages.CallbackNotFound())
+		return false
+	}
+	return true
+}
+
+// findConfig 按 ID 查找订阅，返回订阅和全局序号（从 1 开始）；不存在时返回 nil
+func findConfig(id int64) (*repoConfig, int, error) {
+	configs, err := loadConfigs()
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range configs {
+		if configs[i].ID == id {
+			return &configs[i], i + 1, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+// showListPage 原地更新为列表页
+func showListPage(tg *telegramClient, chatID int64, messageID int, page int) {
+	text, markup, err := buildRepoListMessage(page)
+	if err != nil {
+		log.Printf("Failed to build repo list: %v", err)
+		return
+	}
+	tg.editMessageText(chatID, messageID, text, telegramParseModeMarkdown, markup)
+}
+
+// showSubscription 原地更新为单个订阅的管理界面，订阅已不存在时回到列表页
+func showSubscription(tg *telegramClient, chatID int64, messageID int, id int64, page int) {
+	cfg, index, err := findConfig(id)
+	if err != nil {
+		log.Printf("Failed to load configs: %v", err)
+		return
+	}
+	if cfg == nil {
+		showListPage(tg, chatID, messageID, page)
+		return
+	}
+
+	data := func(action string) string {
+		return fmt.Sprintf("list:%s:%d:%d", action, id, page)
+	}
+	pause := inlineKeyboardButton{Text: "⏸️ 暂停", CallbackData: data("p")}
+	if cfg.Paused {
+		pause = inlineKeyboardButton{Text: "▶️ 恢复", CallbackData: data("p")}
+	}
+	rows := [][]inlineKeyboardButton{
+		{pause, {Text: "🗑️ 删除", CallbackData: data("d")}},
+	}
+	if cfg.Source == sourceGitHub {
+		rows = append(rows, []inlineKeyboardButton{
+			{Text: "🔍 切换监控类型", CallbackData: data("e")},
+			{Text: "🔀 修改分支", CallbackData: data("b")},
+		})
+	}
+	rows = append(rows,
+		[]inlineKeyboardButton{
+			{Text: "📢 移动到其他会话", CallbackData: data("m")},
+			{Text: "🌙 免打扰", CallbackData: data("q")},
+		},
+		[]inlineKeyboardButton{{Text: "« 返回列表", CallbackData: fmt.Sprintf("list:pg:%d", page)}},
+	)
+	tg.editMessageText(chatID, messageID, buildRepoListItem(index, cfg), telegramParseModeMarkdown, inlineKeyboard(rows))
+}
+
+// clearPendingInput 取消等待中的输入
+func clearPendingInput(chatID int64) {
+	pendingInputs.Lock()
+	delete(pendingInputs.byChat, chatID)
+	pendingInputs.Unlock()
+}
+
+// handlePendingInput 处理管理界面等待的回复（新分支名、移动目标或免打扰时段），没有等待的输入时返回 false
+func handlePendingInput(tg *telegramClient, chatID int64, text string) bool {
+	pendingInputs.Lock()
+	input, ok := pendingInputs.byChat[chatID]
+	delete(pendingInputs.byChat, chatID)
+	pendingInputs.Unlock()
+	if !ok || time.Now().After(input.expires) {
+		return false
+	}
+
+	cfg, _, err := findConfig(input.id)
+	if err != nil || cfg == nil {
+		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
+		return true
+	}
+
+	switch input.kind {
+	case "branch":
+		branch := strings.TrimSpace(text)
+		ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+		defer cancel()
+		// 分支不存在时 GitHub 返回 404
+		if _, err := githubAPI.LatestCommit(ctx, cfg.Repo, branch); err != nil || strings.ContainsAny(branch, " \t") {
+			Logger.Debug("Invalid branch %s for %s: %v", branch, cfg.Repo, err)
+			tg.sendMessage(chatID, Messages.ErrorInvalidBranch(), telegramParseModeMarkdown, false, "", 0)
+			return true
+		}
+		_, err = updateConfig(input.id, func(c *repoConfig) bool {
+			if c.Branch == branch {
+				return false
+			}
+			// 切换分支后重新记录，不推送旧提交
+			c.Branch = branch
+			c.LastCommitSHA = nil
+			return true
+		})
+		if err == nil {
+			log.Printf("🔀 Subscription %d now tracks %s:%s", input.id, cfg.Repo, branch)
+		}
+
+	case "move":
+		target := strings.TrimSpace(text)
+		var tgChat *chat
+		if target != "私聊" && !strings.EqualFold(target, "private") {
+			var errMsg string
+			if tgChat, errMsg = resolveNotifyChat(tg, target); errMsg != "" {
+				tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+				return true
+			}
+		}
+		moved := *cfg
+		moved.ThreadID = 0
+		setNotifyChat(&moved, tgChat)
+		if err := createSubscriptionTopic(tg, tgChat, &moved); err != nil {
+			tg.sendMessage(chatID, Messages.ErrorCreateTopic(), telegramParseModeMarkdown, false, "", 0)
+			return true
+		}
+		_, err = updateConfig(input.id, func(c *repoConfig) bool {
+			c.ChannelID, c.ChannelTitle, c.ThreadID = moved.ChannelID, moved.ChannelTitle, moved.ThreadID
+			return true
+		})
+		if err == nil {
+			log.Printf("📢 Subscription %d moved to %s", input.id, moved.ChannelTitle)
+		}
+
+	case "quiet":
+		var window, mode string
+		if value := strings.TrimSpace(text); value != "关闭" && !strings.EqualFold(value, "off") {
+			var ok bool
+			if window, mode, ok = parseQuietHours(value); !ok {
+				tg.sendMessage(chatID, Messages.ErrorQuietHours(), telegramParseModeMarkdown, false, "", 0)
+				return true
+			}
+		}
+		_, err = updateConfig(input.id, func(c *repoConfig) bool {
+			c.QuietHours, c.QuietMode = window, mode
+			return true
+		})
+		if err == nil {
+			log.Printf("🌙 Subscription %d quiet hours: %q %s", input.id, window, mode)
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to update config: %v", err)
+		tg.sendMessage(chatID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, false, "", 0)
+		return true
+	}
+	showSubscription(tg, chatID, input.messageID, input.id, input.page)
+	return true
+}
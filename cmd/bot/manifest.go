@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// manifestDependency 依赖清单中的一个依赖
+type manifestDependency struct {
+	Source string // 包注册表：go/npm/pypi/crates
+	Name   string
+}
+
+// String 返回带来源前缀的依赖名，如 npm:react
+func (d manifestDependency) String() string {
+	return d.Source + ":" + d.Name
+}
+
+// manifestParsers 支持的依赖清单，键为文件名
+var manifestParsers = map[string]struct {
+	source string
+	parse  func(data string) ([]string, error)
+}{
+	"go.mod":           {sourceGo, parseGoMod},
+	"package.json":     {sourceNpm, parsePackageJSON},
+	"requirements.txt": {sourcePyPI, parseRequirements},
+	"Cargo.toml":       {sourceCrates, parseCargoToml},
+}
+
+// parseManifest 按文件名解析依赖清单，返回去重后的依赖列表
+func parseManifest(fileName string, data []byte) ([]manifestDependency, error) {
+	parser, ok := manifestParsers[path.Base(fileName)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported manifest: %s", fileName)
+	}
+	names, err := parser.parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var deps []manifestDependency
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		deps = append(deps, manifestDependency{Source: parser.source, Name: name})
+	}
+	return deps, nil
+}
+
+// parseGoMod 解析 go.mod 中的直接依赖（跳过 // indirect）
+func parseGoMod(data string) ([]string, error) {
+	var modules []string
+	inBlock := false
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "// indirect") {
+			continue
+		}
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inBlock:
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			modules = append(modules, strings.Trim(fields[0], `"`))
+		}
+	}
+	return modules, nil
+}
+
+// parsePackageJSON 解析 package.json 中的 dependencies 和 devDependencies
+func parsePackageJSON(data string) ([]string, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(data), &pkg); err != nil {
+		return nil, fmt.Errorf("invalid package.json: %w", err)
+	}
+	var names []string
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name, spec := range deps {
+			// 跳过本地路径、workspace 等非注册表依赖
+			if strings.HasPrefix(spec, "file:") || strings.HasPrefix(spec, "link:") || strings.HasPrefix(spec, "workspace:") {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+var requirementNameRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// parseRequirements 解析 requirements.txt（跳过 -r、-e 等选项和 URL 依赖）
+func parseRequirements(data string) ([]string, error) {
+	var names []string
+	for _, line := range strings.Split(data, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		if m := requirementNameRegexp.FindStringSubmatch(line); m != nil {
+			names = append(names, strings.ToLower(m[1]))
+		}
+	}
+	return names, nil
+}
+
+var (
+	tomlSectionRegexp = regexp.MustCompile(`^\[([^\]]+)\]$`)
+	tomlKeyRegexp     = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=`)
+)
+
+// parseCargoToml 解析 Cargo.toml 中的 [dependencies]、[dev-dependencies]、[build-dependencies]
+// （包括 [target.*.dependencies] 和 [dependencies.foo] 写法，跳过 path 依赖）
+func parseCargoToml(data string) ([]string, error) {
+	var names []string
+	isDepsTable := func(section string) bool {
+		for _, t := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+			if section == t || strings.HasSuffix(section, "."+t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	inDeps := false
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := tomlSectionRegexp.FindStringSubmatch(line); m != nil {
+			section := strings.TrimSpace(m[1])
+			inDeps = isDepsTable(section)
+			// [dependencies.serde] 形式
+			if i := strings.LastIndex(section, "."); !inDeps && i > 0 && isDepsTable(section[:i]) {
+				names = append(names, section[i+1:])
+			}
+			continue
+		}
+		if !inDeps || strings.Contains(line, "path =") || strings.Contains(line, "workspace = true") {
+			continue
+		}
+		if m := tomlKeyRegexp.FindStringSubmatch(line); m != nil {
+			names = append(names, m[1])
+		}
+	}
+	return names, nil
+}
+
+var gitHubRepoURLRegexp = regexp.MustCompile(`github\.com[/:]([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)`)
+
+// gitHubRepoFromURL 从仓库地址中提取 owner/repo
+// 兼容 https://github.com/o/r、git+https://github.com/o/r.git、git@github.com:o/r.git、github:o/r
+func gitHubRepoFromURL(u string) string {
+	if rest, ok := strings.CutPrefix(u, "github:"); ok {
+		u = "github.com/" + rest
+	}
+	m := gitHubRepoURLRegexp.FindStringSubmatch(u)
+	if m == nil {
+		return ""
+	}
+	repo := m[1] + "/" + strings.TrimSuffix(m[2], ".git")
+	if !repoRegexp.MatchString(repo) {
+		return ""
+	}
+	return repo
+}
+
+// resolveDependencyRepo 将依赖解析为 GitHub 仓库（owner/repo），无法解析时返回空字符串
+func resolveDependencyRepo(ctx context.Context, dep manifestDependency) (string, error) {
+	switch dep.Source {
+	case sourceGo:
+		return resolveGoModuleRepo(ctx, dep.Name)
+	case sourceNpm:
+		var doc struct {
+			Repository json.RawMessage `json:"repository"`
+		}
+		endpoint := "https://registry.npmjs.org/" + strings.Replace(dep.Name, "/", "%2F", 1) + "/latest"
+		if err := getRegistryJSON(ctx, endpoint, "", &doc); err != nil {
+			return "", err
+		}
+		// repository 可以是字符串或 {type, url}
+		var repoURL string
+		if json.Unmarshal(doc.Repository, &repoURL) != nil {
+			var obj struct {
+				URL string `json:"url"`
+			}
+			json.Unmarshal(doc.Repository, &obj)
+			repoURL = obj.URL
+		}
+		return gitHubRepoFromURL(repoURL), nil
+	case sourcePyPI:
+		var doc struct {
+			Info struct {
+				HomePage    string            `json:"home_page"`
+				ProjectURLs map[string]string `json:"project_urls"`
+			} `json:"info"`
+		}
+		if err := getRegistryJSON(ctx, "https://pypi.org/pypi/"+dep.Name+"/json", "", &doc); err != nil {
+			return "", err
+		}
+		// 优先 Source/Repository 等链接，其次主页
+		for _, key := range []string{"Source", "Source Code", "Repository", "Code", "GitHub", "Homepage"} {
+			if repo := gitHubRepoFromURL(doc.Info.ProjectURLs[key]); repo != "" {
+				return repo, nil
+			}
+		}
+		for _, u := range doc.Info.ProjectURLs {
+			if repo := gitHubRepoFromURL(u); repo != "" {
+				return repo, nil
+			}
+		}
+		return gitHubRepoFromURL(doc.Info.HomePage), nil
+	case sourceCrates:
+		var doc struct {
+			Crate struct {
+				Repository string `json:"repository"`
+				Homepage   string `json:"homepage"`
+			} `json:"crate"`
+		}
+		if err := getRegistryJSON(ctx, "https://crates.io/api/v1/crates/"+dep.Name, "", &doc); err != nil {
+			return "", err
+		}
+		if repo := gitHubRepoFromURL(doc.Crate.Repository); repo != "" {
+			return repo, nil
+		}
+		return gitHubRepoFromURL(doc.Crate.Homepage), nil
+	}
+	return "", nil
+}
+
+var (
+	goImportMetaRegexp = regexp.MustCompile(`(?is)<meta\s[^>]*name=["']?go-import["']?[^>]*>`)
+	metaContentRegexp  = regexp.MustCompile(`(?is)content=["']([^"']+)["']`)
+)
+
+// resolveGoModuleRepo 解析 Go 模块的源码仓库：
+// github.com 路径直接截取；否则先查模块代理 @latest 返回的 Origin，再查 vanity 导入路径的 go-import meta 标签
+func resolveGoModuleRepo(ctx context.Context, module string) (string, error) {
+	if strings.HasPrefix(module, "github.com/") {
+		return gitHubRepoFromURL(module), nil
+	}
+
+	var info struct {
+		Origin struct {
+			URL string `json:"URL"`
+		} `json:"Origin"`
+	}
+	if err := getRegistryJSON(ctx, goProxyURL+"/"+escapeModulePath(module)+"/@latest", "", &info); err == nil {
+		if repo := gitHubRepoFromURL(info.Origin.URL); repo != "" {
+			return repo, nil
+		}
+	} else if !isNotFound(err) {
+		Logger.Debug("  ⚠️ Go proxy lookup failed for %s: %v", module, err)
+	}
+
+	var page string
+	if err := getRegistryJSON(ctx, "https://"+module+"?go-get=1", "", &page); err != nil {
+		return "", err
+	}
+	for _, tag := range goImportMetaRegexp.FindAllString(page, -1) {
+		// content="<导入前缀> <vcs> <仓库地址>"
+		m := metaContentRegexp.FindStringSubmatch(tag)
+		if m == nil {
+			continue
+		}
+		fields := strings.Fields(html.UnescapeString(m[1]))
+		if len(fields) == 3 && (module == fields[0] || strings.HasPrefix(module, fields[0]+"/")) {
+			return gitHubRepoFromURL(fields[2]), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		file, data string
+		want       []manifestDependency
+	}{
+		{
+			file: "go.mod",
+			data: `module example.com/app
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	github.com/google/uuid v1.6.0 // pinned
+	golang.org/x/sys v0.20.0 // indirect
+	"github.com/quoted/mod" v1.0.0
+)
+
+replace github.com/old/mod => ../mod
+`,
+			want: []manifestDependency{
+				{sourceGo, "github.com/spf13/cobra"},
+				{sourceGo, "github.com/google/uuid"},
+				{sourceGo, "github.com/quoted/mod"},
+			},
+		},
+		{
+			file: "web/package.json",
+			data: `{
+  "dependencies": {"react": "^18.0.0", "local": "file:../local", "shared": "workspace:*"},
+  "devDependencies": {"@types/node": "^20.0.0", "react": "^18.0.0"}
+}`,
+			want: []manifestDependency{
+				{sourceNpm, "@types/node"},
+				{sourceNpm, "react"},
+			},
+		},
+		{
+			file: "requirements.txt",
+			data: `# tools
+-r base.txt
+-e git+https://github.com/o/r.git#egg=r
+Django>=4.2  # web
+requests[socks]==2.31.0
+git+https://github.com/o/other.git
+numpy
+django
+`,
+			want: []manifestDependency{
+				{sourcePyPI, "django"},
+				{sourcePyPI, "requests"},
+				{sourcePyPI, "numpy"},
+			},
+		},
+		{
+			file: "Cargo.toml",
+			data: `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1", features = ["derive"] }
+local = { path = "../local" }
+shared = { workspace = true }
+tokio = "1"
+
+[dev-dependencies]
+# comment
+criterion = "0.5"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[dependencies.reqwest]
+version = "0.12"
+
+[features]
+default = []
+`,
+			want: []manifestDependency{
+				{sourceCrates, "serde"},
+				{sourceCrates, "tokio"},
+				{sourceCrates, "criterion"},
+				{sourceCrates, "libc"},
+				{sourceCrates, "reqwest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseManifest(tt.file, []byte(tt.data))
+		if err != nil {
+			t.Errorf("parseManifest(%s) error: %v", tt.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseManifest(%s) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	if _, err := parseManifest("pom.xml", nil); err == nil {
+		t.Error("parseManifest(pom.xml) succeeded, want unsupported manifest error")
+	}
+	if _, err := parseManifest("package.json", []byte("{")); err == nil {
+		t.Error("parseManifest(invalid package.json) succeeded, want error")
+	}
+}
+
+func TestGitHubRepoFromURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://github.com/owner/repo", "owner/repo"},
+		{"git+https://github.com/owner/repo.git", "owner/repo"},
+		{"git@github.com:owner/repo.git", "owner/repo"},
+		{"github:owner/repo", "owner/repo"},
+		{"https://github.com/owner/repo/tree/main/pkg", "owner/repo"},
+		{"https://gitlab.com/owner/repo", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := gitHubRepoFromURL(tt.in); got != tt.want {
+			t.Errorf("gitHubRepoFromURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
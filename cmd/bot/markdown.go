@@ -237,3 +237,315 @@ func (m *mdv2) JoinLines(parts ...string) string {
 func (m *mdv2) Nbsp(parts ...string) string {
 	return strings.Join(parts, " ")
 }
+
+// ============================================
+// 消息拆分
+// ============================================
+
+// utf16Len 按 UTF-16 编码单元计算长度（Telegram 计算消息长度的方式）
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// splitBlock 拆分时所处的块级结构
+type splitBlock struct {
+	fence      string // 未闭合代码块的起始行（如 ```go），为空表示不在代码块中
+	expandable bool   // 在未闭合的可展开引用块中
+}
+
+// closer 在当前位置截断时需要追加的闭合标记
+func (b splitBlock) closer() string {
+	switch {
+	case b.fence != "":
+		return "\n```"
+	case b.expandable:
+		return "||"
+	}
+	return ""
+}
+
+// next 返回经过一行之后的块级状态
+func (b splitBlock) next(line string) splitBlock {
+	switch {
+	case b.fence != "":
+		if strings.HasPrefix(line, "```") {
+			b.fence = ""
+		}
+	case strings.HasPrefix(line, "```"):
+		b.fence = line
+	case strings.HasPrefix(line, "**>"):
+		b.expandable = !strings.HasSuffix(line, "||")
+	case b.expandable:
+		b.expandable = strings.HasPrefix(line, ">") && !strings.HasSuffix(line, "||")
+	}
+	return b
+}
+
+// Split 将超长的 MarkdownV2 文本拆分为多段，每段不超过 limit 个字符
+// 优先在行间拆分；不会截断转义序列和链接，代码块、可展开引用块和行内格式在段尾闭合、在下一段重新打开
+func (m *mdv2) Split(text string, limit int) []string {
+	if utf16Len(text) <= limit {
+		return []string{text}
+	}
+
+	var parts []string
+	var cur strings.Builder
+	curLen := 0
+	content := false // 当前段是否已有内容（不含代码块起始行）
+	fenceAt := -1    // 当前段中刚打开、还没有内容的代码块起始行的位置
+	state := splitBlock{}
+
+	write := func(line string) {
+		if cur.Len() > 0 {
+			cur.WriteByte('\n')
+			curLen++
+		}
+		cur.WriteString(line)
+		curLen += utf16Len(line)
+	}
+	finish := func() {
+		cur.WriteString(state.closer())
+		parts = append(parts, cur.String())
+		cur.Reset()
+		curLen, content, fenceAt = 0, false, -1
+		// 在新段开头重新打开代码块
+		if state.fence != "" {
+			write(state.fence)
+		}
+	}
+
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		// 可展开引用块跨段时，新段的第一行重新作为可展开引用的开头
+		if !content && state.expandable && strings.HasPrefix(line, ">") {
+			line = "**" + line
+		}
+		after := state.next(line)
+		need := utf16Len(line) + utf16Len(after.closer())
+		if cur.Len() > 0 {
+			need++
+		}
+		if curLen+need <= limit {
+			at := cur.Len()
+			write(line)
+			// 代码块起始行不算内容：其后的长行应在本段内截断，而不是留下只有 ``` 的空段
+			if state.fence == "" && after.fence != "" {
+				fenceAt = at
+			} else {
+				content, fenceAt = true, -1
+			}
+			state = after
+			i++
+			continue
+		}
+		if content {
+			if fenceAt >= 0 {
+				// 代码块起始行之后的第一行就放不下：把起始行移到下一段
+				kept := cur.String()[:fenceAt]
+				cur.Reset()
+				cur.WriteString(kept)
+				curLen = utf16Len(kept)
+				state.fence = ""
+				i--
+			}
+			finish()
+			continue
+		}
+
+		// 单行超过一段的长度：在行内安全位置截断
+		room := limit - curLen - utf16Len(state.closer())
+		if cur.Len() > 0 {
+			room--
+		}
+		head, tail := splitLine(line, room, state.fence != "")
+		write(head)
+		finish()
+		lines[i] = tail
+	}
+	if content {
+		cur.WriteString(state.closer())
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
+
+// splitLine 在不超过 room 个字符的安全位置截断一行
+// 不会截断转义序列和链接；行内格式在前半部分闭合、在后半部分重新打开，引用行的后半部分保留 > 前缀
+func splitLine(line string, room int, inCode bool) (string, string) {
+	runes := []rune(line)
+	quote := ""
+	start := 0
+	if !inCode {
+		if strings.HasPrefix(line, "**>") {
+			quote, start = ">", 3
+		} else if strings.HasPrefix(line, ">") {
+			quote, start = ">", 1
+		}
+	}
+
+	// 预留闭合行内格式的空间
+	const reserve = 12
+	limit, size := start, 0
+	for limit < len(runes) {
+		w := utf16Len(string(runes[limit]))
+		if size+w > room-reserve {
+			break
+		}
+		size += w
+		limit++
+	}
+
+	// 扫描行内格式，记录不超过 limit 的最后一个安全位置（优先空白处）
+	var stack []string
+	cut, spaceCut := -1, -1
+	var cutStack, spaceStack []string
+	inInlineCode, inLink := false, false
+	toggle := func(marker string) {
+		for j := len(stack) - 1; j >= 0; j-- {
+			if stack[j] == marker {
+				stack = append(stack[:j], stack[j+1:]...)
+				return
+			}
+		}
+		stack = append(stack, marker)
+	}
+	for i := start; i <= limit && i < len(runes); {
+		if !inLink && i > start {
+			cut, cutStack = i, append([]string(nil), stack...)
+			if runes[i] == ' ' {
+				spaceCut, spaceStack = i, cutStack
+			}
+		}
+		r := runes[i]
+		switch {
+		case r == '\\':
+			i += 2
+			continue
+		case inCode:
+		case r == '`':
+			inInlineCode = !inInlineCode
+			toggle("`")
+		case inInlineCode:
+		case inLink:
+			if r == ')' {
+				inLink = false
+			}
+		case r == '[':
+			inLink = true
+		case r == '*' || r == '~':
+			toggle(string(r))
+		case (r == '|' || r == '_') && i+1 < len(runes) && runes[i+1] == r:
+			toggle(string([]rune{r, r}))
+			i += 2
+			continue
+		case r == '_':
+			toggle("_")
+		}
+		i++
+	}
+	if spaceCut > start && limit-spaceCut < 80 {
+		cut, cutStack = spaceCut, spaceStack
+	}
+	if cut <= start {
+		// 没有安全位置（如超长链接），只能强制截断
+		cut, cutStack = limit, nil
+		if cut <= start && start < len(runes) {
+			cut = start + 1
+		}
+	}
+
+	var closers, openers strings.Builder
+	for j := len(cutStack) - 1; j >= 0; j-- {
+		closers.WriteString(cutStack[j])
+	}
+	for _, marker := range cutStack {
+		openers.WriteString(marker)
+	}
+	head := string(runes[:cut]) + closers.String()
+	tail := quote + openers.String() + strings.TrimLeft(string(runes[cut:]), " ")
+	return head, tail
+}
+
+// ============================================
+// 纯文本转换
+// ============================================
+
+// PlainText 将 MarkdownV2 文本转换为纯文本：去掉格式标记、引用前缀和转义，链接保留地址
+// 用于 Telegram 无法解析实体时以纯文本重新发送，尽量保留原有内容
+func (m *mdv2) PlainText(text string) string {
+	var out []string
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, unescapeCode(line))
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "**>"); ok {
+			line = strings.TrimSuffix(rest, "||")
+		} else if rest, ok := strings.CutPrefix(line, ">"); ok {
+			line = strings.TrimSuffix(rest, "||")
+		}
+		out = append(out, plainLine(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+// unescapeCode 去掉代码块中的转义
+func unescapeCode(line string) string {
+	var b strings.Builder
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\\' && i+1 < len(runes) {
+			i++
+		}
+		b.WriteRune(runes[i])
+	}
+	return b.String()
+}
+
+// plainLine 去掉一行普通文本中的格式标记和转义，[文本](地址) 转为「文本 (地址)」
+func plainLine(line string) string {
+	var b strings.Builder
+	runes := []rune(line)
+	linkText := -1 // 当前链接文本在输出中的起始位置
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			b.WriteRune(runes[i])
+		case r == '[':
+			linkText = b.Len()
+		case r == ']' && i+1 < len(runes) && runes[i+1] == '(':
+			var url strings.Builder
+			for i += 2; i < len(runes) && runes[i] != ')'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				url.WriteRune(runes[i])
+			}
+			if linkText < 0 || b.String()[linkText:] != url.String() {
+				b.WriteString(" (" + url.String() + ")")
+			}
+			linkText = -1
+		case strings.ContainsRune("*_~|`]", r):
+			// 格式标记
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{"short", "*title*\nbody", 100, []string{"*title*\nbody"}},
+		{"between lines", "aaaa\nbbbb\ncccc", 10, []string{"aaaa\nbbbb", "cccc"}},
+		{
+			"code block reopened",
+			"*t*\n```go\nline1\nline2\nline3\n```\nend", 20,
+			[]string{"*t*\n```go\nline1\n```", "```go\nline2\n```", "```go\nline3\n```\nend"},
+		},
+		{
+			"expandable quote reopened",
+			"**>one\n>two\n>three||", 12,
+			[]string{"**>one||", "**>two||", "**>three||"},
+		},
+	}
+	for _, tt := range tests {
+		if got := MDV2.Split(tt.text, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Split = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSplitLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+	}{
+		{"long code line after fence", "*title*\n```\n" + strings.Repeat("x", 5000) + "\n```", maxMessageLength},
+		{"long code line at start", "```\n" + strings.Repeat("y", 9000) + "\n```", maxMessageLength},
+		{"many lines", strings.Repeat(MDV2.Escape("line with some text.")+"\n", 600), maxMessageLength},
+		{"long bold line", MDV2.Bold(strings.Repeat("word ", 400)), 300},
+		{"long link line", strings.Repeat(MDV2.Link("text", "https://example.com/a_b")+" ", 100), 300},
+		{"emoji", strings.Repeat("😀", 3000), maxMessageLength},
+	}
+	for _, tt := range tests {
+		parts := MDV2.Split(tt.text, tt.limit)
+		if len(parts) < 2 {
+			t.Errorf("%s: Split returned %d part(s), want several", tt.name, len(parts))
+		}
+		for i, part := range parts {
+			if n := utf16Len(part); n > tt.limit {
+				t.Errorf("%s: part %d has %d chars, limit %d", tt.name, i, n, tt.limit)
+			}
+			fences, content := 0, false
+			for _, line := range strings.Split(part, "\n") {
+				if strings.HasPrefix(line, "```") {
+					fences++
+				} else if line != "" {
+					content = true
+				}
+			}
+			if fences%2 != 0 {
+				t.Errorf("%s: part %d has an unclosed code block: %q", tt.name, i, part)
+			}
+			if !content {
+				t.Errorf("%s: part %d has no content: %q", tt.name, i, part)
+			}
+		}
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"*Bold* _italic_ __under__ ~strike~ ||spoiler||", "Bold italic under strike spoiler"},
+		{`1\.2\.3 \- \*not bold\*`, "1.2.3 - *not bold*"},
+		{"[link](https://a.b/c_d)", "link (https://a.b/c_d)"},
+		{"[https://a.b](https://a.b)", "https://a.b"},
+		{"`inline`", "inline"},
+		{"```go\nfmt.Println(\"\\`\")\n```", "fmt.Println(\"`\")"},
+		{"**>quote\n>more||", "quote\nmore"},
+		{">single", "single"},
+	}
+	for _, tt := range tests {
+		if got := MDV2.PlainText(tt.in); got != tt.want {
+			t.Errorf("PlainText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -21,18 +21,78 @@ var Messages = struct {
 	ErrorDeleteFormat    func() string
 	ErrorInvalidIndex    func() string
 	ErrorCreateTopic     func() string
+	ErrorInterval        func() string
+	ErrorTagFilter       func() string
+	ErrorAssetFilter     func() string
+	ErrorAssetSize       func() string
+	ErrorQuietHours      func() string
+	ErrorInvalidImage    func() string
+	ErrorInvalidPackage  func() string
+	ErrorInvalidFeed     func() string
+	ErrorManifest        func() string
 
 	// 成功消息
-	SuccessAdded   func(repo, target, monitorType, branchInfo string) string
+	SuccessAdded   func(repo, target, monitorType, branchInfo, interval string) string
 	SuccessDeleted func(repo string) string
 
+	// 依赖清单导入
+	ImportStarted func(fileName string, count int) string
+	ImportReport  func(target string, added []string, existing int, unresolved []string) string
+
 	// 列表
 	ListHeader func() string
-	ListItem   func(index int, repo, branchInfo, monitorType, target string) string
+	ListItem      func(index int, repo, branchInfo, monitorType, target string, extras ...string) string
+	ListItemExtra func(label, value string) string
 
 	// 通知
-	NotifyRelease func(repo, tag, body, translation, url string) string
-	NotifyCommit  func(repoName, branch, message, translation, url string) string
+	NotifyRelease  func(repo, tag, body, translation, changes, url string) string
+	ReleaseCommits func(groups []commitGroup, more int, compareURL string) string
+	NotifyCommit   func(repoName, branch, message, translation, url string) string
+
+	// 容器镜像通知
+	NotifyImageTags   func(image string, tags []string, url string) string
+	NotifyImageDigest func(image, tag, oldDigest, newDigest, url string) string
+
+	// 订阅源通知
+	NotifyFeed func(feedTitle, title, content, translation, url string) string
+
+	// Star 导入
+	StarsEmpty       func() string
+	StarsSelect      func(source string, total, selected, page, pages int, sync bool) string
+	StarsCancelled   func() string
+	StarsAdding      func(count int) string
+	StarsSyncEnabled func(source string) string
+	StarsSynced      func(source string, added, failed []string) string
+
+	// 超长消息截断提示
+	MessageTruncated func() string
+
+	// 通知多次发送失败提醒
+	OutboxFailing func(name string, chatID int64, attempts int, lastError string) string
+
+	// 会话变化
+	ChatMigrated       func(title string, oldID, newID int64, moved []string) string
+	ChatAccessLost     func(title, by string, paused []string) string
+	ChatAccessRestored func(title string, resumed []string) string
+
+	// 订阅管理界面
+	ManageConfirmDelete func(repo string) string
+	ManagePromptBranch  func(repo string) string
+	ManagePromptMove    func(repo string) string
+	ManagePromptQuiet   func(repo string) string
+	ErrorInvalidBranch  func() string
+
+	// 按钮回调提示（纯文本）
+	CallbackExpired         func() string
+	CallbackNothingSelected func() string
+	CallbackPaused          func() string
+	CallbackResumed         func() string
+	CallbackFailed          func() string
+	CallbackNotFound        func() string
+
+	// GitHub Token 用量
+	TokensEmpty func() string
+	TokenUsage  func(usage []tokenUsage) string
 }{
 	// ============================================
 	// 帮助消息
@@ -43,7 +103,7 @@ var Messages = struct {
 			"",
 			MDV2.Bold("可用命令："),
 			"",
-			MDV2.Nbsp("•", MDV2.CodeRaw("/list"), "\\-", "查看所有监控的仓库"),
+			MDV2.Nbsp("•", MDV2.CodeRaw("/list"), "\\-", "查看所有监控的仓库，点击序号可暂停、删除、修改或移动"),
 			"",
 			MDV2.Nbsp("•", MDV2.CodeRaw("/add"), "\\-", "添加仓库监控"),
 			MDV2.Nbsp(" ", "格式：", MDV2.CodeRaw("/add owner/repo[:branch] [选项]")),
@@ -51,7 +111,27 @@ var Messages = struct {
 			"  选项：",
 			MDV2.Nbsp(" ", MDV2.CodeRaw("-r"), ":", "监控 Release"),
 			MDV2.Nbsp(" ", MDV2.CodeRaw("-c"), ":", "监控 Commit"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-l"), ":", "Release 通知附带与上一版本之间的提交"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-f <通配符>"), ":", "将匹配的 Release 附件作为文件回复到通知下"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-m 20MB"), ":", "附件大小上限（默认和最大 50MB）"),
 			MDV2.Nbsp(" ", MDV2.CodeRaw("@group"), ":", "发送到指定频道/群组"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-i 10m"), ":", "自定义检查间隔"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-a"), ":", "根据仓库活跃度自动调整检查间隔"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-q 23:00-07:00"), ":", "免打扰时段内静默发送，加", MDV2.CodeRaw("/hold"), "暂存到时段结束，加", MDV2.CodeRaw("/drop"), "丢弃"),
+			"",
+			"  容器镜像：",
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add docker.io/library/nginx"), ":", "监控新标签"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-t <正则>"), ":", "只监控匹配的标签"),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("-d"), ":", "监控标签 digest 变化（默认 latest）"),
+			"",
+			"  语言包：",
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add go:golang.org/x/net")),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add npm:react")),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add pypi:requests")),
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add crates:serde")),
+			"",
+			"  RSS/Atom 订阅源：",
+			MDV2.Nbsp(" ", MDV2.CodeRaw("/add https://example.com/releases.atom")),
 			"",
 			"  示例：",
 			MDV2.Nbsp(" ", MDV2.CodeRaw("/add nginx/nginx:master -r")),
@@ -61,6 +141,14 @@ var Messages = struct {
 			MDV2.Nbsp("•", MDV2.CodeRaw("/delete <序号>"), "\\-", "删除监控"),
 			MDV2.Nbsp(" ", "示例：", MDV2.CodeRaw("/delete 1")),
 			"",
+			MDV2.Nbsp("•", MDV2.CodeRaw("/tokens"), "\\-", "查看 GitHub Token 额度"),
+			"",
+			MDV2.Nbsp("•", MDV2.CodeRaw("/stars [用户名] [@group] [-s]"), "\\-", "从 Star 列表勾选仓库批量订阅"),
+			MDV2.Nbsp(" ", "不填用户名时列出 Token 所有者 Watch 的仓库，", MDV2.CodeRaw("-s"), "持续同步新 Star"),
+			"",
+			"• 发送 go\\.mod、package\\.json、requirements\\.txt 或 Cargo\\.toml 文件，批量订阅依赖的 Release",
+			MDV2.Nbsp(" ", "文件说明中可填写", MDV2.CodeRaw("@group"), "指定通知目标"),
+			"",
 			MDV2.Bold("提示："),
 			"• 默认监控 Release 和 Commit",
 			MDV2.Nbsp("•", "用", MDV2.CodeRaw(":branch"), "快速指定其他分支"),
@@ -153,10 +241,85 @@ var Messages = struct {
 		)
 	},
 
+	ErrorInterval: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("检查间隔无效")),
+			"",
+			MDV2.Nbsp("请使用", MDV2.CodeRaw("30s"), "、", MDV2.CodeRaw("10m"), "、", MDV2.CodeRaw("2h"), "等格式"),
+			"最短间隔为 30 秒",
+		)
+	},
+
+	ErrorTagFilter: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("标签过滤规则无效")),
+			"",
+			MDV2.Nbsp("请使用正则表达式，例如：", MDV2.CodeRaw("^1\\\\.\\\\d+$")),
+		)
+	},
+
+	ErrorAssetFilter: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("附件过滤规则无效")),
+			"",
+			MDV2.Nbsp("请使用文件名通配符，多个用逗号分隔，例如：", MDV2.CodeRaw("*linux_amd64.tar.gz,*.deb")),
+		)
+	},
+
+	ErrorAssetSize: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("附件大小上限无效")),
+			"",
+			MDV2.Nbsp("请使用", MDV2.CodeRaw("20MB"), "、", MDV2.CodeRaw("512KB"), "等格式，最大", MDV2.CodeRaw("50MB")),
+		)
+	},
+
+	ErrorQuietHours: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("免打扰时段无效")),
+			"",
+			MDV2.Nbsp("请使用", MDV2.CodeRaw("23:00-07:00"), "格式，可加", MDV2.CodeRaw("/silent"), "、", MDV2.CodeRaw("/hold"), "或", MDV2.CodeRaw("/drop")),
+		)
+	},
+
+	ErrorInvalidImage: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("镜像不存在或无法访问")),
+			"",
+			MDV2.Nbsp("请使用", MDV2.CodeRaw("registry/name[:tag]"), "格式"),
+			MDV2.Nbsp("例如：", MDV2.CodeRaw("docker.io/library/nginx"), "、", MDV2.CodeRaw("ghcr.io/owner/image")),
+		)
+	},
+
+	ErrorInvalidPackage: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("包不存在或无法访问")),
+			"",
+			MDV2.Nbsp("支持的前缀：", MDV2.CodeRaw("go:"), MDV2.CodeRaw("npm:"), MDV2.CodeRaw("pypi:"), MDV2.CodeRaw("crates:")),
+			MDV2.Nbsp("例如：", MDV2.CodeRaw("npm:react")),
+		)
+	},
+
+	ErrorInvalidFeed: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("订阅源无法解析")),
+			"",
+			"请确认地址可以访问，且为 RSS 2\\.0 或 Atom 格式",
+		)
+	},
+
+	ErrorManifest: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("无法解析依赖清单")),
+			"",
+			"支持 go\\.mod、package\\.json、requirements\\.txt 和 Cargo\\.toml",
+		)
+	},
+
 	// ============================================
 	// 成功消息
 	// ============================================
-	SuccessAdded: func(repo, target, monitorType, branchInfo string) string {
+	SuccessAdded: func(repo, target, monitorType, branchInfo, interval string) string {
 		lines := []string{
 			MDV2.Bold("添加成功"),
 			"",
@@ -167,6 +330,9 @@ var Messages = struct {
 		if branchInfo != "" {
 			lines = append(lines, MDV2.Nbsp("🔀", MDV2.Bold("分支") + ":", MDV2.CodeRaw(branchInfo)))
 		}
+		if interval != "" {
+			lines = append(lines, MDV2.Nbsp("⏱️", MDV2.Bold("频率") + ":", interval))
+		}
 		lines = append(lines, "", "监控已启动，将在发现更新时通知你")
 		return MDV2.JoinLines(lines...)
 	},
@@ -179,6 +345,28 @@ var Messages = struct {
 		)
 	},
 
+	ImportStarted: func(fileName string, count int) string {
+		return MDV2.Nbsp("⏳", MDV2.Escape(fmt.Sprintf("正在解析 %s 中的 %d 个依赖…", fileName, count)))
+	},
+
+	ImportReport: func(target string, added []string, existing int, unresolved []string) string {
+		lines := []string{
+			MDV2.Bold("导入完成"),
+			"",
+			MDV2.Nbsp("📢", MDV2.Bold("通知") + ":", target),
+			MDV2.Nbsp("✅", MDV2.Bold("新增") + ":", MDV2.Escape(fmt.Sprintf("%d 个仓库", len(added)))),
+		}
+		lines = append(lines, reportItems(added)...)
+		if existing > 0 {
+			lines = append(lines, MDV2.Nbsp("♻️", MDV2.Bold("已存在") + ":", MDV2.Escape(fmt.Sprintf("%d 个仓库", existing))))
+		}
+		if len(unresolved) > 0 {
+			lines = append(lines, MDV2.Nbsp("⚠️", MDV2.Bold("无法解析") + ":", MDV2.Escape(fmt.Sprintf("%d 个依赖", len(unresolved)))))
+			lines = append(lines, reportItems(unresolved)...)
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
 	// ============================================
 	// 列表消息
 	// ============================================
@@ -186,7 +374,7 @@ var Messages = struct {
 		return MDV2.Nbsp("📚", MDV2.Bold("已监控的仓库"))
 	},
 
-	ListItem: func(index int, repo, branchInfo, monitorType, target string) string {
+	ListItem: func(index int, repo, branchInfo, monitorType, target string, extras ...string) string {
 		// 格式: *1\.* `owner/repo:branch`
 		//       └─ 监控: Release + Commit
 		//       └─ 通知: 私聊
@@ -194,17 +382,22 @@ var Messages = struct {
 		if branchInfo != "" {
 			repoDisplay = repo + ":" + branchInfo
 		}
-		return MDV2.JoinLines(
+		lines := []string{
 			fmt.Sprintf("*%d\\.* %s", index, MDV2.CodeRaw(repoDisplay)),
 			fmt.Sprintf("└─ 监控: %s", monitorType),
 			fmt.Sprintf("└─ 通知: %s", target),
-		)
+		}
+		return MDV2.JoinLines(append(lines, extras...)...)
+	},
+
+	ListItemExtra: func(label, value string) string {
+		return fmt.Sprintf("└─ %s: %s", label, value)
 	},
 
 	// ============================================
 	// 通知消息
 	// ============================================
-	NotifyRelease: func(repo, tag, body, translation, url string) string {
+	NotifyRelease: func(repo, tag, body, translation, changes, url string) string {
 		var lines []string
 
 		// 标题
@@ -224,6 +417,11 @@ var Messages = struct {
 			)
 		}
 
+		// 与上一个 Release 之间的提交
+		if changes != "" {
+			lines = append(lines, "", changes)
+		}
+
 		// 链接
 		lines = append(lines,
 			"",
@@ -233,6 +431,23 @@ var Messages = struct {
 		return MDV2.JoinLines(lines...)
 	},
 
+	ReleaseCommits: func(groups []commitGroup, more int, compareURL string) string {
+		lines := []string{MDV2.Bold("包含的提交") + ":"}
+		for _, g := range groups {
+			lines = append(lines, MDV2.Escape(g.Label))
+			for _, title := range g.Commits {
+				lines = append(lines, "• "+MDV2.Escape(title))
+			}
+		}
+		if more > 0 {
+			lines = append(lines, MDV2.Italic(MDV2.Escape(fmt.Sprintf("…以及另外 %d 个提交", more))))
+		}
+		if compareURL != "" {
+			lines = append(lines, MDV2.Link("查看对比", compareURL))
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
 	NotifyCommit: func(repoName, branch, message, translation, url string) string {
 		var lines []string
 
@@ -240,7 +455,7 @@ var Messages = struct {
 		lines = append(lines,
 			MDV2.Nbsp("🔨", MDV2.Bold(fmt.Sprintf("new commits to %s:%s", MDV2.Escape(repoName), MDV2.Escape(branch)))),
 			"",
-			MDV2.CodeBlockRaw(message),
+			MDV2.CodeBlock(message),
 		)
 
 		// 翻译（如果有）
@@ -260,4 +475,315 @@ var Messages = struct {
 
 		return MDV2.JoinLines(lines...)
 	},
+	NotifyImageTags: func(image string, tags []string, url string) string {
+		var lines []string
+
+		// 标题
+		lines = append(lines,
+			MDV2.Nbsp("🐳", MDV2.Bold("new image tag")),
+			"",
+			"📦 "+MDV2.Escape(image),
+		)
+
+		shown := tags
+		if len(shown) > maxTagsPerMessage {
+			shown = shown[:maxTagsPerMessage]
+		}
+		for _, tag := range shown {
+			lines = append(lines, "└─ "+MDV2.Code(tag))
+		}
+		if len(tags) > len(shown) {
+			lines = append(lines, MDV2.Escape(fmt.Sprintf("└─ … 共 %d 个新标签", len(tags))))
+		}
+
+		// 链接
+		if url != "" {
+			lines = append(lines,
+				"",
+				MDV2.Link("查看详情", url),
+			)
+		}
+
+		return MDV2.JoinLines(lines...)
+	},
+
+	NotifyImageDigest: func(image, tag, oldDigest, newDigest, url string) string {
+		lines := []string{
+			MDV2.Nbsp("🐳", MDV2.Bold("image updated")),
+			"",
+			"📦 " + MDV2.Escape(image) + ":" + MDV2.Escape(tag),
+			"└─ " + MDV2.Code(shortDigest(oldDigest)) + " → " + MDV2.Code(shortDigest(newDigest)),
+		}
+		if url != "" {
+			lines = append(lines,
+				"",
+				MDV2.Link("查看详情", url),
+			)
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
+	NotifyFeed: func(feedTitle, title, content, translation, url string) string {
+		var lines []string
+
+		// 标题
+		lines = append(lines, MDV2.Nbsp("📰", MDV2.Bold("new post")), "")
+		if feedTitle != "" {
+			lines = append(lines, "📦 "+MDV2.Escape(feedTitle))
+		}
+		lines = append(lines, "└─ "+MDV2.Bold(MDV2.Escape(title)))
+
+		// 正文：有翻译时展示译文，否则展示原文摘要
+		if translation != "" {
+			lines = append(lines,
+				"",
+				MDV2.Bold("摘要") + ":",
+				MDV2.BlockquoteEscaped(translation),
+			)
+		} else if content != "" {
+			lines = append(lines,
+				"",
+				MDV2.BlockquoteEscaped(content),
+			)
+		}
+
+		// 链接
+		if url != "" {
+			lines = append(lines,
+				"",
+				MDV2.Link("查看详情", url),
+			)
+		}
+
+		return MDV2.JoinLines(lines...)
+	},
+
+	// ============================================
+	// Star 导入
+	// ============================================
+	StarsEmpty: func() string {
+		return MDV2.Nbsp("📭", MDV2.Bold("没有找到 Star 或 Watch 的仓库"))
+	},
+
+	StarsSelect: func(source string, total, selected, page, pages int, sync bool) string {
+		lines := []string{
+			MDV2.Nbsp("⭐", MDV2.Bold("选择要订阅 Release 的仓库")),
+			"",
+			MDV2.Nbsp("📦", MDV2.Bold("来源") + ":", MDV2.Code(source)),
+			MDV2.Escape(fmt.Sprintf("共 %d 个仓库，已选 %d 个（第 %d/%d 页）", total, selected, page, pages)),
+		}
+		if sync {
+			lines = append(lines, "", "确认后将持续同步新 Star 的仓库")
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
+	StarsCancelled: func() string {
+		return MDV2.Nbsp("✖️", MDV2.Bold("已取消导入"))
+	},
+
+	StarsAdding: func(count int) string {
+		return MDV2.Nbsp("⏳", MDV2.Escape(fmt.Sprintf("正在添加 %d 个仓库…", count)))
+	},
+
+	StarsSyncEnabled: func(source string) string {
+		return MDV2.Nbsp("🔄", "已开启", MDV2.Code(source), "同步，新 Star 的仓库将自动订阅 Release")
+	},
+
+	StarsSynced: func(source string, added, failed []string) string {
+		lines := []string{
+			MDV2.Nbsp("⭐", MDV2.Bold("新 Star 已同步")),
+			"",
+			MDV2.Nbsp("📦", MDV2.Bold("来源") + ":", MDV2.Code(source)),
+		}
+		lines = append(lines, reportItems(added)...)
+		if len(failed) > 0 {
+			lines = append(lines, MDV2.Nbsp("⚠️", MDV2.Bold("添加失败") + ":"))
+			lines = append(lines, reportItems(failed)...)
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
+	MessageTruncated: func() string {
+		return MDV2.Italic(MDV2.Escape("…内容过长，已截断"))
+	},
+
+	OutboxFailing: func(name string, chatID int64, attempts int, lastError string) string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("⚠️", MDV2.Bold("通知发送失败")),
+			"",
+			MDV2.Nbsp("📦", MDV2.Bold("订阅") + ":", MDV2.Code(name)),
+			MDV2.Nbsp("💬", MDV2.Bold("目标") + ":", MDV2.Code(fmt.Sprint(chatID))),
+			MDV2.Nbsp("🔁", MDV2.Bold("已失败") + ":", MDV2.Escape(fmt.Sprintf("%d 次", attempts))),
+			MDV2.Nbsp("❗", MDV2.Bold("错误") + ":", MDV2.Code(lastError)),
+			"",
+			MDV2.Italic(MDV2.Escape("通知仍保留在发件箱中，会继续重试")),
+		)
+	},
+
+	// ============================================
+	// 会话变化
+	// ============================================
+	ChatMigrated: func(title string, oldID, newID int64, moved []string) string {
+		lines := []string{
+			MDV2.Nbsp("🔀", MDV2.Bold("群组已升级为超级群组")),
+			"",
+			MDV2.Nbsp("💬", MDV2.Bold("群组") + ":", MDV2.Escape(title)),
+			MDV2.Nbsp("🆔", MDV2.Code(fmt.Sprint(oldID)), "→", MDV2.Code(fmt.Sprint(newID))),
+			"",
+			MDV2.Bold(MDV2.Escape(fmt.Sprintf("已迁移 %d 个订阅：", len(moved)))),
+		}
+		for _, name := range moved {
+			lines = append(lines, "└─ "+MDV2.Code(name))
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
+	ChatAccessLost: func(title, by string, paused []string) string {
+		lines := []string{
+			MDV2.Nbsp("🚫", MDV2.Bold("机器人已无法在会话中发消息")),
+			"",
+			MDV2.Nbsp("💬", MDV2.Bold("会话") + ":", MDV2.Escape(title)),
+		}
+		if by != "" {
+			lines = append(lines, MDV2.Nbsp("👤", MDV2.Bold("操作者") + ":", MDV2.Escape(by)))
+		}
+		lines = append(lines, "", MDV2.Bold(MDV2.Escape(fmt.Sprintf("已暂停 %d 个订阅：", len(paused)))))
+		for _, name := range paused {
+			lines = append(lines, "└─ "+MDV2.Code(name))
+		}
+		lines = append(lines, "", MDV2.Italic(MDV2.Escape("重新添加机器人或恢复发消息权限后自动恢复")))
+		return MDV2.JoinLines(lines...)
+	},
+
+	ChatAccessRestored: func(title string, resumed []string) string {
+		lines := []string{
+			MDV2.Nbsp("✅", MDV2.Bold("机器人已恢复会话权限")),
+			"",
+			MDV2.Nbsp("💬", MDV2.Bold("会话") + ":", MDV2.Escape(title)),
+			"",
+			MDV2.Bold(MDV2.Escape(fmt.Sprintf("已恢复 %d 个订阅：", len(resumed)))),
+		}
+		for _, name := range resumed {
+			lines = append(lines, "└─ "+MDV2.Code(name))
+		}
+		return MDV2.JoinLines(lines...)
+	},
+
+	// ============================================
+	// 订阅管理界面
+	// ============================================
+	ManageConfirmDelete: func(repo string) string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("🗑️", MDV2.Bold("确认删除该订阅？")),
+			"",
+			MDV2.CodeRaw(repo),
+		)
+	},
+
+	ManagePromptBranch: func(repo string) string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("🔀", MDV2.Bold("修改分支")),
+			"",
+			MDV2.Nbsp("请回复", MDV2.CodeRaw(repo), "要监控的分支名"),
+		)
+	},
+
+	ManagePromptMove: func(repo string) string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("📢", MDV2.Bold("移动订阅")),
+			"",
+			MDV2.Nbsp("请回复", MDV2.CodeRaw(repo), "的新通知目标："),
+			MDV2.Nbsp(MDV2.CodeRaw("@group"), "、群组 ID 或", MDV2.CodeRaw("私聊")),
+		)
+	},
+
+	ManagePromptQuiet: func(repo string) string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("🌙", MDV2.Bold("免打扰")),
+			"",
+			MDV2.Nbsp("请回复", MDV2.CodeRaw(repo), "的免打扰时段（时区", MDV2.Escape(quietZone())+"），如", MDV2.CodeRaw("23:00-07:00")),
+			MDV2.Nbsp("默认静默发送，加", MDV2.CodeRaw("/hold"), "暂存到时段结束再发送，加", MDV2.CodeRaw("/drop"), "丢弃"),
+			MDV2.Nbsp("回复", MDV2.CodeRaw("关闭"), "取消免打扰"),
+		)
+	},
+
+	ErrorInvalidBranch: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("❌", MDV2.Bold("分支不存在")),
+			"",
+			"请在 /list 中重新选择修改分支",
+		)
+	},
+
+	CallbackExpired: func() string {
+		return "会话已过期，请重新发送命令"
+	},
+
+	CallbackNothingSelected: func() string {
+		return "请先选择至少一个仓库"
+	},
+
+	CallbackPaused: func() string {
+		return "已暂停"
+	},
+
+	CallbackResumed: func() string {
+		return "已恢复"
+	},
+
+	CallbackFailed: func() string {
+		return "操作失败，请稍后重试"
+	},
+
+	CallbackNotFound: func() string {
+		return "该订阅已不存在"
+	},
+
+	// ============================================
+	// GitHub Token 用量
+	// ============================================
+	TokensEmpty: func() string {
+		return MDV2.JoinLines(
+			MDV2.Nbsp("🔑", MDV2.Bold("未配置 GitHub Token")),
+			"",
+			MDV2.Nbsp("当前以匿名身份访问 GitHub API，限额 60 次/小时"),
+		)
+	},
+
+	TokenUsage: func(usage []tokenUsage) string {
+		lines := []string{MDV2.Nbsp("🔑", MDV2.Bold("GitHub Token 额度")), ""}
+		for _, u := range usage {
+			var status string
+			switch {
+			case u.Invalid:
+				status = "❌ 已失效"
+			case u.Remaining < 0:
+				status = "尚未使用"
+			case u.Remaining == 0:
+				status = MDV2.Escape(fmt.Sprintf("⏳ 已耗尽，%s 重置", u.Reset.Format("15:04")))
+			default:
+				status = MDV2.Escape(fmt.Sprintf("%d/%d 剩余，%s 重置", u.Remaining, u.Limit, u.Reset.Format("15:04")))
+			}
+			lines = append(lines,
+				MDV2.Nbsp("•", MDV2.Code(u.Label)),
+				fmt.Sprintf("└─ 状态: %s", status),
+				fmt.Sprintf("└─ 请求: %d 次", u.Requests),
+			)
+		}
+		return MDV2.JoinLines(lines...)
+	},
+}
+
+// reportItems 报告中的条目列表，最多列出 maxReportItems 个，其余只显示数量
+func reportItems(items []string) []string {
+	var lines []string
+	for i, item := range items {
+		if i == maxReportItems {
+			lines = append(lines, "└─ "+MDV2.Escape(fmt.Sprintf("…还有 %d 个", len(items)-i)))
+			break
+		}
+		lines = append(lines, "└─ "+MDV2.Code(item))
+	}
+	return lines
 }
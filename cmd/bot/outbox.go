@@ -0,0 +1,398 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// outboxItem 通知发件箱中的一条通知
+// 检查到事件后先写入发件箱再推进订阅状态，Telegram 确认送达后才标记为已发送
+type outboxItem struct {
+	ID          int64      `json:"id"`
+	Key         string     `json:"key"` // 事件去重键，同一事件重复检测到时不会再次入队
+	SubID       int64      `json:"sub_id"`
+	Name        string     `json:"name"` // 订阅展示名称，用于告警
+	ChatID      int64      `json:"chat_id"`
+	ThreadID    int64      `json:"thread_id,omitempty"`
+	Text        string     `json:"text"`
+	Created     time.Time  `json:"created"`
+	Attempts    int        `json:"attempts,omitempty"`
+	NextAttempt time.Time  `json:"next_attempt"`
+	LastError   string     `json:"last_error,omitempty"`
+	Delivered   *time.Time `json:"delivered,omitempty"`
+	Held        bool       `json:"held,omitempty"` // 机器人失去会话权限，暂不发送，恢复权限后继续
+
+	// 超长通知拆分后已送达的各条消息 ID，重试时从第一条未送达的继续，不重复发送
+	PartIDs []int `json:"part_ids,omitempty"`
+
+	// Release 附件，通知送达后作为文档回复到通知下
+	Repo       string        `json:"repo,omitempty"`
+	Assets     []gitHubAsset `json:"assets,omitempty"`
+	MessageID  int           `json:"message_id,omitempty"`  // 通知已送达但附件未上传完成时记录，重试时不再重发通知
+	AssetsDone []string      `json:"assets_done,omitempty"` // 已上传（或已跳过）的附件名，重试时不再重复上传
+
+	// 订阅的免打扰设置，发送时按当时的时间判断
+	QuietHours string `json:"quiet_hours,omitempty"`
+	QuietMode  string `json:"quiet_mode,omitempty"`
+}
+
+var outbox = struct {
+	sync.Mutex
+	items  []outboxItem
+	loaded bool
+	busy   map[int64]bool // 正在发送的会话，同一会话同时只有一个发送协程
+	wake   chan struct{}
+}{busy: make(map[int64]bool), wake: make(chan struct{}, 1)}
+
+// enqueueNotification 将通知写入发件箱，返回 nil 表示已持久化（不代表已送达）；assets 为要附带上传的 Release 附件
+// key 相同的通知已在发件箱中时直接返回，避免重启后重复检测到同一事件时重复发送；
+// 订阅处于免打扰时段且设置为丢弃时不入队，设置为暂存时推迟到时段结束再发送
+func enqueueNotification(cfg *repoConfig, chatID int64, key, text string, assets []gitHubAsset) error {
+	outbox.Lock()
+	defer outbox.Unlock()
+	if err := loadOutboxLocked(); err != nil {
+		return err
+	}
+
+	var maxID int64
+	for _, item := range outbox.items {
+		if item.Key == key {
+			Logger.Debug("  ℹ️ Notification %s already in outbox", key)
+			return nil
+		}
+		if item.ID > maxID {
+			maxID = item.ID
+		}
+	}
+	now := time.Now()
+	next := now
+	if until, quiet := quietUntil(cfg.QuietHours, now); quiet {
+		switch cfg.QuietMode {
+		case quietDrop:
+			log.Printf("🌙 Dropping notification %s for %s during quiet hours", key, subscriptionName(cfg))
+			return nil
+		case quietHold:
+			Logger.Debug("🌙 Holding notification %s until %s", key, until.Format(time.RFC3339))
+			next = until
+		}
+	}
+	outbox.items = append(outbox.items, outboxItem{
+		ID:          maxID + 1,
+		Key:         key,
+		SubID:       cfg.ID,
+		Name:        subscriptionName(cfg),
+		ChatID:      chatID,
+		ThreadID:    cfg.ThreadID,
+		Text:        text,
+		Created:     now,
+		NextAttempt: next,
+		Assets:      assets,
+		QuietHours:  cfg.QuietHours,
+		QuietMode:   cfg.QuietMode,
+	})
+	if len(assets) > 0 {
+		outbox.items[len(outbox.items)-1].Repo = cfg.Repo
+	}
+	if err := writeOutboxLocked(); err != nil {
+		outbox.items = outbox.items[:len(outbox.items)-1]
+		return err
+	}
+
+	select {
+	case outbox.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// runOutbox 后台发送发件箱中的通知：启动时先补发上次未送达的通知，之后在新通知入队或定时唤醒
+func runOutbox(tg *telegramClient, adminID int64) {
+	for {
+		deliverOutbox(tg, adminID)
+		select {
+		case <-outbox.wake:
+		case <-time.After(outboxTick):
+		}
+	}
+}
+
+// deliverOutbox 发送所有到期的通知
+// 每个会话一个独立的发送协程，不等待其他会话（慢会话不会拖住其他会话）；
+// 同一会话按入队顺序发送，前一条失败时后面的等下一轮，保证通知顺序；会话仍在发送时本轮跳过
+func deliverOutbox(tg *telegramClient, adminID int64) {
+	outbox.Lock()
+	if err := loadOutboxLocked(); err != nil {
+		outbox.Unlock()
+		return
+	}
+	now := time.Now()
+	var (
+		kept    []outboxItem
+		chats   []int64
+		due     = make(map[int64][]outboxItem)
+		changed bool
+	)
+	for _, item := range outbox.items {
+		switch {
+		case item.Delivered != nil:
+			if now.Sub(*item.Delivered) > outboxRetention {
+				continue
+			}
+		case now.Sub(item.Created) > outboxMaxAge:
+			log.Printf("🗑️ Dropping notification %s for %s after %d attempts: %s", item.Key, item.Name, item.Attempts, item.LastError)
+			continue
+		case item.Held:
+		case !item.NextAttempt.After(now):
+			// 重试时进入了免打扰时段，暂存的通知推迟到时段结束
+			if until, quiet := quietUntil(item.QuietHours, now); quiet && item.QuietMode == quietHold {
+				item.NextAttempt = until
+				changed = true
+				break
+			}
+			if outbox.busy[item.ChatID] {
+				break
+			}
+			if _, ok := due[item.ChatID]; !ok {
+				chats = append(chats, item.ChatID)
+			}
+			due[item.ChatID] = append(due[item.ChatID], item)
+		}
+		kept = append(kept, item)
+	}
+	if changed || len(kept) != len(outbox.items) {
+		outbox.items = kept
+		if err := writeOutboxLocked(); err != nil {
+			log.Printf("❌ Failed to save outbox: %v", err)
+		}
+	}
+	for _, chatID := range chats {
+		outbox.busy[chatID] = true
+	}
+	outbox.Unlock()
+
+	for _, chatID := range chats {
+		go deliverOutboxChat(tg, adminID, chatID, due[chatID])
+	}
+}
+
+// deliverOutboxChat 按顺序发送同一会话的到期通知，遇到失败即停止；结束后唤醒发件箱处理期间新到期的通知
+func deliverOutboxChat(tg *telegramClient, adminID, chatID int64, items []outboxItem) {
+	defer func() {
+		outbox.Lock()
+		delete(outbox.busy, chatID)
+		outbox.Unlock()
+		select {
+		case outbox.wake <- struct{}{}:
+		default:
+		}
+	}()
+	for _, item := range items {
+		err := deliverOutboxItem(tg, &item)
+		finishOutboxItem(tg, adminID, &item, err)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// deliverOutboxItem 发送通知（之前已送达的部分不再重发），再上传附件；发送进度记录在 item 中
+// 处于免打扰时段时静默发送
+func deliverOutboxItem(tg *telegramClient, item *outboxItem) error {
+	_, silent := quietUntil(item.QuietHours, time.Now())
+	if item.MessageID == 0 {
+		sent, err := tg.sendNotification(item.ChatID, item.ThreadID, item.Text, silent, item.PartIDs)
+		item.PartIDs = sent
+		if err != nil {
+			return err
+		}
+		if len(sent) > 0 {
+			item.MessageID = sent[0]
+		}
+	}
+	if pending := pendingAssets(item); len(pending) > 0 {
+		done, err := uploadReleaseAssets(tg, item.ChatID, item.ThreadID, item.MessageID, item.Repo, pending, silent)
+		item.AssetsDone = append(item.AssetsDone, done...)
+		return err
+	}
+	return nil
+}
+
+// pendingAssets 返回尚未上传的附件
+func pendingAssets(item *outboxItem) []gitHubAsset {
+	done := make(map[string]bool, len(item.AssetsDone))
+	for _, name := range item.AssetsDone {
+		done[name] = true
+	}
+	var pending []gitHubAsset
+	for _, asset := range item.Assets {
+		if !done[asset.Name] {
+			pending = append(pending, asset)
+		}
+	}
+	return pending
+}
+
+// finishOutboxItem 记录一次发送结果和发送进度：成功时标记已送达，失败时按指数退避安排下次重试，
+// 连续失败 outboxAlertAfter 次时私聊提醒管理员
+func finishOutboxItem(tg *telegramClient, adminID int64, sent *outboxItem, sendErr error) {
+	outbox.Lock()
+	var alert *outboxItem
+	for i := range outbox.items {
+		item := &outbox.items[i]
+		if item.ID != sent.ID {
+			continue
+		}
+		now := time.Now()
+		item.MessageID = sent.MessageID
+		item.PartIDs = sent.PartIDs
+		item.AssetsDone = sent.AssetsDone
+		if sendErr == nil {
+			item.Delivered = &now
+			item.LastError = ""
+			Logger.Debug("📨 Delivered notification %s to %d", item.Key, item.ChatID)
+			break
+		}
+		item.Attempts++
+		item.LastError = sendErr.Error()
+		delay := outboxBaseBackoff << uint(item.Attempts-1)
+		if delay > outboxMaxBackoff || delay <= 0 {
+			delay = outboxMaxBackoff
+		}
+		item.NextAttempt = now.Add(delay)
+		log.Printf("📮 Notification %s for %s failed (attempt %d), retrying in %s: %v", item.Key, item.Name, item.Attempts, delay, sendErr)
+		if item.Attempts == outboxAlertAfter {
+			copied := *item
+			alert = &copied
+		}
+		break
+	}
+	if err := writeOutboxLocked(); err != nil {
+		log.Printf("❌ Failed to save outbox: %v", err)
+	}
+	outbox.Unlock()
+
+	if alert != nil {
+		msg := Messages.OutboxFailing(alert.Name, alert.ChatID, alert.Attempts, alert.LastError)
+		if _, err := tg.sendMessage(adminID, msg, telegramParseModeMarkdown, true, "", 0); err != nil {
+			log.Printf("⚠️ Failed to alert admin about notification %s: %v", alert.Key, err)
+		}
+	}
+}
+
+// migrateOutboxChat 将发往旧会话 ID 的待发送通知改到新 ID
+func migrateOutboxChat(oldID, newID int64) {
+	outbox.Lock()
+	defer outbox.Unlock()
+	if err := loadOutboxLocked(); err != nil {
+		return
+	}
+	changed := false
+	for i := range outbox.items {
+		if outbox.items[i].ChatID == oldID && outbox.items[i].Delivered == nil {
+			outbox.items[i].ChatID = newID
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := writeOutboxLocked(); err != nil {
+		log.Printf("❌ Failed to save outbox: %v", err)
+	}
+}
+
+// holdOutboxChat 机器人失去会话权限时暂停发往该会话的待发送通知，恢复权限时立即重新发送
+func holdOutboxChat(chatID int64, hold bool) {
+	outbox.Lock()
+	defer outbox.Unlock()
+	if err := loadOutboxLocked(); err != nil {
+		return
+	}
+	now := time.Now()
+	count := 0
+	for i := range outbox.items {
+		item := &outbox.items[i]
+		if item.ChatID != chatID || item.Delivered != nil || item.Held == hold {
+			continue
+		}
+		item.Held = hold
+		if !hold && item.NextAttempt.After(now) {
+			item.NextAttempt = now
+		}
+		count++
+	}
+	if count == 0 {
+		return
+	}
+	if hold {
+		log.Printf("⏸️ Holding %d notification(s) for chat %d until access returns", count, chatID)
+	} else {
+		log.Printf("▶️ Releasing %d held notification(s) for chat %d", count, chatID)
+	}
+	if err := writeOutboxLocked(); err != nil {
+		log.Printf("❌ Failed to save outbox: %v", err)
+	}
+	if !hold {
+		select {
+		case outbox.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// loadOutboxLocked 首次使用时读取发件箱文件（调用方需持有 outbox 锁）
+func loadOutboxLocked() error {
+	if outbox.loaded {
+		return nil
+	}
+	data, err := os.ReadFile(outboxFile)
+	if errors.Is(err, os.ErrNotExist) {
+		outbox.loaded = true
+		return nil
+	}
+	if err != nil {
+		log.Printf("❌ Failed to read outbox: %v", err)
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &outbox.items); err != nil {
+			log.Printf("❌ Failed to parse outbox: %v", err)
+			return fmt.Errorf("corrupt outbox file, please check %s: %w", outboxFile, err)
+		}
+	}
+	outbox.loaded = true
+
+	pending := 0
+	for _, item := range outbox.items {
+		if item.Delivered == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		log.Printf("📮 %d undelivered notification(s) in outbox", pending)
+	}
+	return nil
+}
+
+// writeOutboxLocked 写入发件箱文件（调用方需持有 outbox 锁）
+// 先写临时文件再重命名，避免写入中途崩溃损坏发件箱
+func writeOutboxLocked() error {
+	if err := os.MkdirAll(filepath.Dir(outboxFile), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(outbox.items, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := outboxFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, outboxFile)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// packageVersion 包注册表中的一个版本
+type packageVersion struct {
+	Version string
+	URL     string // 版本详情页
+}
+
+// packageRegistry 语言包注册表来源
+type packageRegistry struct {
+	Label  string // 展示名称，如 npm、PyPI
+	latest func(ctx context.Context, name string) (*packageVersion, error)
+}
+
+// packageRegistries 支持的包注册表，键为订阅前缀（/add go:golang.org/x/net）
+var packageRegistries = map[string]*packageRegistry{
+	sourceGo:     {Label: "Go", latest: latestGoModule},
+	sourceNpm:    {Label: "npm", latest: latestNpmPackage},
+	sourcePyPI:   {Label: "PyPI", latest: latestPyPIPackage},
+	sourceCrates: {Label: "crates.io", latest: latestCrate},
+}
+
+// parsePackageRef 解析带前缀的包名，如 npm:react
+func parsePackageRef(ref string) (source, name string, ok bool) {
+	prefix, name, found := strings.Cut(ref, ":")
+	if !found || name == "" {
+		return "", "", false
+	}
+	prefix = strings.ToLower(prefix)
+	if prefix == "crate" || prefix == "cargo" {
+		prefix = sourceCrates
+	}
+	if _, ok := packageRegistries[prefix]; !ok {
+		return "", "", false
+	}
+	return prefix, name, true
+}
+
+// getRegistryJSON 请求包注册表 JSON 接口
+func getRegistryJSON(ctx context.Context, endpoint, accept string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "newrelease (https://github.com/52Lxcloud/newrelease)")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	Logger.Debug("📦 Registry API: GET %s", endpoint)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return upstreamNetworkError("package registry", endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return upstreamStatusError("package registry", endpoint, resp.StatusCode)
+	}
+
+	if s, ok := result.(*string); ok {
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryTextSize))
+		*s = string(data)
+		return err
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// escapeModulePath 按模块代理协议转义模块路径：大写字母转为 "!" + 小写
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// latestGoModule 通过 Go 模块代理获取最新版本：优先 /@v/list 中的语义化版本，没有时使用 /@latest
+func latestGoModule(ctx context.Context, module string) (*packageVersion, error) {
+	base := goProxyURL + "/" + escapeModulePath(module)
+
+	var list string
+	if err := getRegistryJSON(ctx, base+"/@v/list", "", &list); err != nil {
+		return nil, err
+	}
+	version := latestVersion(strings.Fields(list))
+	if version == "" {
+		var info struct {
+			Version string `json:"Version"`
+		}
+		if err := getRegistryJSON(ctx, base+"/@latest", "", &info); err != nil {
+			return nil, err
+		}
+		version = info.Version
+	}
+	if version == "" {
+		return nil, nil
+	}
+	return &packageVersion{
+		Version: version,
+		URL:     fmt.Sprintf("https://pkg.go.dev/%s@%s", module, version),
+	}, nil
+}
+
+// latestNpmPackage 通过 npm registry 获取 latest 标签对应的版本
+func latestNpmPackage(ctx context.Context, name string) (*packageVersion, error) {
+	// 作用域包 @scope/name 需要转义斜杠
+	endpoint := "https://registry.npmjs.org/" + strings.Replace(name, "/", "%2F", 1)
+	var doc struct {
+		DistTags map[string]string `json:"dist-tags"`
+	}
+	if err := getRegistryJSON(ctx, endpoint, "application/vnd.npm.install-v1+json", &doc); err != nil {
+		return nil, err
+	}
+	version := doc.DistTags["latest"]
+	if version == "" {
+		return nil, nil
+	}
+	return &packageVersion{
+		Version: version,
+		URL:     fmt.Sprintf("https://www.npmjs.com/package/%s/v/%s", name, version),
+	}, nil
+}
+
+// latestPyPIPackage 通过 PyPI JSON API 获取最新版本
+func latestPyPIPackage(ctx context.Context, name string) (*packageVersion, error) {
+	endpoint := fmt.Sprintf("https://pypi.org/pypi/%s/json", url.PathEscape(name))
+	var doc struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := getRegistryJSON(ctx, endpoint, "", &doc); err != nil {
+		return nil, err
+	}
+	if doc.Info.Version == "" {
+		return nil, nil
+	}
+	return &packageVersion{
+		Version: doc.Info.Version,
+		URL:     fmt.Sprintf("https://pypi.org/project/%s/%s/", name, doc.Info.Version),
+	}, nil
+}
+
+// latestCrate 通过 crates.io API 获取最新稳定版本
+func latestCrate(ctx context.Context, name string) (*packageVersion, error) {
+	endpoint := "https://crates.io/api/v1/crates/" + url.PathEscape(name)
+	var doc struct {
+		Crate struct {
+			MaxStableVersion string `json:"max_stable_version"`
+			MaxVersion       string `json:"max_version"`
+		} `json:"crate"`
+	}
+	if err := getRegistryJSON(ctx, endpoint, "", &doc); err != nil {
+		return nil, err
+	}
+	version := doc.Crate.MaxStableVersion
+	if version == "" {
+		version = doc.Crate.MaxVersion
+	}
+	if version == "" {
+		return nil, nil
+	}
+	return &packageVersion{
+		Version: version,
+		URL:     fmt.Sprintf("https://crates.io/crates/%s/%s", name, version),
+	}, nil
+}
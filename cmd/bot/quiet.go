@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // scratch 镜像中没有时区数据库，内置一份以支持 TZ 环境变量
+)
+
+// 免打扰时段内的处理方式
+const (
+	quietSilent = "silent" // 静默发送（disable_notification）
+	quietHold   = "hold"   // 暂存到时段结束再发送
+	quietDrop   = "drop"   // 丢弃
+)
+
+// quietModeNames 免打扰方式的展示名称
+var quietModeNames = map[string]string{
+	quietSilent: "静默发送",
+	quietHold:   "结束后发送",
+	quietDrop:   "丢弃",
+}
+
+// parseQuietHours 解析免打扰设置，如 23:00-07:00、23:00-07:00/hold，返回规范化的时段和方式
+func parseQuietHours(s string) (window, mode string, ok bool) {
+	window, mode, _ = strings.Cut(strings.TrimSpace(s), "/")
+	if mode == "" {
+		mode = quietSilent
+	}
+	mode = strings.ToLower(mode)
+	if _, ok := quietModeNames[mode]; !ok {
+		return "", "", false
+	}
+	start, end, ok := quietWindow(window)
+	if !ok || start == end {
+		return "", "", false
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", start/60, start%60, end/60, end%60), mode, true
+}
+
+// quietWindow 解析时段的起止时间（当天分钟数），如 23:00-07:00
+func quietWindow(window string) (start, end int, ok bool) {
+	from, to, found := strings.Cut(window, "-")
+	if !found {
+		return 0, 0, false
+	}
+	parse := func(s string) (int, bool) {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, false
+		}
+		return t.Hour()*60 + t.Minute(), true
+	}
+	if start, ok = parse(from); !ok {
+		return 0, 0, false
+	}
+	end, ok = parse(to)
+	return start, end, ok
+}
+
+// quietUntil 判断 now 是否在免打扰时段内（按 TZ 配置的本地时区），在时段内时返回时段结束的时间
+// 起始晚于结束表示跨午夜，如 23:00-07:00
+func quietUntil(window string, now time.Time) (time.Time, bool) {
+	start, end, ok := quietWindow(window)
+	if !ok || start == end {
+		return time.Time{}, false
+	}
+	now = now.In(time.Local)
+	minute := now.Hour()*60 + now.Minute()
+	var inWindow bool
+	if start < end {
+		inWindow = minute >= start && minute < end
+	} else {
+		inWindow = minute >= start || minute < end
+	}
+	if !inWindow {
+		return time.Time{}, false
+	}
+
+	until := time.Date(now.Year(), now.Month(), now.Day(), end/60, end%60, 0, 0, time.Local)
+	if minute >= end {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// describeQuietHours 免打扰设置的展示文本，如 23:00-07:00 静默发送
+func describeQuietHours(cfg *repoConfig) string {
+	if cfg.QuietHours == "" {
+		return ""
+	}
+	mode := cfg.QuietMode
+	if mode == "" {
+		mode = quietSilent
+	}
+	return fmt.Sprintf("%s %s（%s）", cfg.QuietHours, quietModeNames[mode], quietZone())
+}
+
+// quietZone 免打扰时段使用的时区名称，未配置 TZ 时显示时区缩写
+func quietZone() string {
+	if name := time.Local.String(); name != "Local" {
+		return name
+	}
+	return time.Now().Format("MST")
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	tests := []struct {
+		in, window, mode string
+		ok               bool
+	}{
+		{"23:00-07:00", "23:00-07:00", quietSilent, true},
+		{" 9:05-17:30 ", "09:05-17:30", quietSilent, true},
+		{"23:00-07:00/hold", "23:00-07:00", quietHold, true},
+		{"22:00-06:00/DROP", "22:00-06:00", quietDrop, true},
+		{"23:00-07:00/silent", "23:00-07:00", quietSilent, true},
+		{"23:00-07:00/later", "", "", false},
+		{"08:00-08:00", "", "", false},
+		{"25:00-07:00", "", "", false},
+		{"23:00", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		window, mode, ok := parseQuietHours(tt.in)
+		if window != tt.window || mode != tt.mode || ok != tt.ok {
+			t.Errorf("parseQuietHours(%q) = %q, %q, %v; want %q, %q, %v", tt.in, window, mode, ok, tt.window, tt.mode, tt.ok)
+		}
+	}
+}
+
+func TestQuietUntil(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		window string
+		now    time.Time
+		until  time.Time
+		quiet  bool
+	}{
+		{"23:00-07:00", at(10, 23, 30), at(11, 7, 0), true},
+		{"23:00-07:00", at(10, 2, 0), at(10, 7, 0), true},
+		{"23:00-07:00", at(10, 23, 0), at(11, 7, 0), true},
+		{"23:00-07:00", at(10, 7, 0), time.Time{}, false},
+		{"23:00-07:00", at(10, 12, 0), time.Time{}, false},
+		{"12:00-14:00", at(10, 13, 59), at(10, 14, 0), true},
+		{"12:00-14:00", at(10, 11, 59), time.Time{}, false},
+		{"", at(10, 12, 0), time.Time{}, false},
+		{"08:00-08:00", at(10, 8, 0), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		until, quiet := quietUntil(tt.window, tt.now)
+		if quiet != tt.quiet || !until.Equal(tt.until) {
+			t.Errorf("quietUntil(%q, %s) = %s, %v; want %s, %v", tt.window, tt.now.Format("15:04"), until, quiet, tt.until, tt.quiet)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// imageRef 容器镜像引用，如 docker.io/library/nginx:latest
+type imageRef struct {
+	Registry string // 镜像仓库主机，如 docker.io、ghcr.io、localhost:5000
+	Name     string // 仓库内的镜像名，如 library/nginx
+	Tag      string // 可选的标签
+}
+
+// String 返回不带标签的规范名称
+func (r imageRef) String() string {
+	return r.Registry + "/" + r.Name
+}
+
+// WebURL 返回镜像在网页上的地址，未知镜像仓库返回空字符串
+func (r imageRef) WebURL() string {
+	switch r.Registry {
+	case "docker.io":
+		if strings.HasPrefix(r.Name, "library/") {
+			return "https://hub.docker.com/_/" + strings.TrimPrefix(r.Name, "library/")
+		}
+		return "https://hub.docker.com/r/" + r.Name
+	case "ghcr.io":
+		return "https://ghcr.io/" + r.Name
+	case "quay.io":
+		return "https://quay.io/repository/" + r.Name
+	}
+	return ""
+}
+
+var imageNameRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*$`)
+
+// parseImageRef 解析镜像引用，第一段必须是镜像仓库主机（含 "." 或 ":"，或为 localhost）
+func parseImageRef(ref string) (imageRef, bool) {
+	slash := strings.Index(ref, "/")
+	if slash <= 0 {
+		return imageRef{}, false
+	}
+	host := ref[:slash]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return imageRef{}, false
+	}
+
+	rest := ref[slash+1:]
+	tag := ""
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		rest, tag = rest[:i], rest[i+1:]
+		if tag == "" {
+			return imageRef{}, false
+		}
+	}
+	if !imageNameRegexp.MatchString(rest) {
+		return imageRef{}, false
+	}
+
+	host = strings.ToLower(host)
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		host = "docker.io"
+	}
+	// Docker Hub 官方镜像位于 library/ 下
+	if host == "docker.io" && !strings.Contains(rest, "/") {
+		rest = "library/" + rest
+	}
+	return imageRef{Registry: host, Name: rest, Tag: tag}, true
+}
+
+// registryToken 镜像仓库 Bearer Token
+type registryToken struct {
+	token   string
+	expires time.Time
+}
+
+// registryClient OCI Distribution API 客户端
+// 遇到 401 时按 WWW-Authenticate 质询获取 Bearer Token（Docker Hub、GHCR 等）
+type registryClient struct {
+	httpClient  *http.Client
+	credentials map[string][2]string // 主机 -> 用户名、密码
+
+	mu     sync.Mutex
+	tokens map[string]registryToken // realm|service|scope -> token
+}
+
+// registryAPI 全局镜像仓库客户端
+var registryAPI = newRegistryClient(httpClient, os.Getenv("REGISTRY_CREDENTIALS"))
+
+// newRegistryClient 创建镜像仓库客户端
+// credentials 格式：host=user:password，多个用分号分隔
+func newRegistryClient(client *http.Client, credentials string) *registryClient {
+	c := &registryClient{
+		httpClient:  client,
+		credentials: make(map[string][2]string),
+		tokens:      make(map[string]registryToken),
+	}
+	for _, entry := range strings.Split(credentials, ";") {
+		host, cred, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		user, pass, _ := strings.Cut(cred, ":")
+		c.credentials[strings.ToLower(host)] = [2]string{user, pass}
+	}
+	return c
+}
+
+// baseURL 返回镜像仓库 API 地址；本地镜像仓库使用 http
+func (c *registryClient) baseURL(registry string) string {
+	if registry == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	host := registry
+	if h, _, ok := strings.Cut(registry, ":"); ok {
+		host = h
+	}
+	if host == "localhost" || host == "127.0.0.1" {
+		return "http://" + registry
+	}
+	return "https://" + registry
+}
+
+// do 发送请求，遇到 Bearer 质询时获取 Token 后重试一次
+func (c *registryClient) do(ctx context.Context, ref imageRef, method, endpoint string, header http.Header) (*http.Response, error) {
+	send := func(token string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("User-Agent", "newrelease")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if cred, ok := c.credentials[ref.Registry]; ok {
+			req.SetBasicAuth(cred[0], cred[1])
+		}
+		Logger.Debug("🐳 Registry API: %s %s", method, endpoint)
+		return c.httpClient.Do(req)
+	}
+
+	resp, err := send("")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return nil, fmt.Errorf("registry %s requires unsupported auth: %q", ref.Registry, challenge)
+	}
+	token, err := c.token(ctx, ref, parseAuthChallenge(challenge[len("bearer "):]))
+	if err != nil {
+		return nil, err
+	}
+	return send(token)
+}
+
+// token 按质询参数获取 Bearer Token（带缓存）
+func (c *registryClient) token(ctx context.Context, ref imageRef, params map[string]string) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry %s sent a challenge without realm", ref.Registry)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.Name + ":pull"
+	}
+	key := realm + "|" + params["service"] + "|" + scope
+
+	c.mu.Lock()
+	if tok, ok := c.tokens[key]; ok && time.Now().Before(tok.expires) {
+		c.mu.Unlock()
+		return tok.token, nil
+	}
+	c.mu.Unlock()
+
+	q := url.Values{}
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	q.Set("scope", scope)
+	req, err := http.NewRequestWithContext(ctx, "GET", realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "newrelease")
+	if cred, ok := c.credentials[ref.Registry]; ok {
+		req.SetBasicAuth(cred[0], cred[1])
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	token := body.Token
+	if token == "" {
+		token = body.AccessToken
+	}
+	ttl := time.Duration(body.ExpiresIn) * time.Second
+	if ttl <= 0 {
+		ttl = 60 * time.Second
+	}
+
+	c.mu.Lock()
+	c.tokens[key] = registryToken{token: token, expires: time.Now().Add(ttl - 10*time.Second)}
+	c.mu.Unlock()
+	return token, nil
+}
+
+// parseAuthChallenge 解析 WWW-Authenticate 参数：realm="...",service="...",scope="..."
+func parseAuthChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.Index(s[1:], `"`)
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else if comma := strings.Index(s, ","); comma >= 0 {
+			value, s = s[:comma], s[comma:]
+		} else {
+			value, s = s, ""
+		}
+		params[key] = value
+	}
+	return params
+}
+
+// ListTags 列出镜像的所有标签（自动翻页）
+func (c *registryClient) ListTags(ctx context.Context, ref imageRef) ([]string, error) {
+	base := c.baseURL(ref.Registry)
+	endpoint := fmt.Sprintf("%s/v2/%s/tags/list?n=%d", base, ref.Name, registryPageSize)
+
+	var tags []string
+	for endpoint != "" {
+		resp, err := c.do(ctx, ref, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, upstreamStatusError("registry", endpoint, resp.StatusCode)
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		link := resp.Header.Get("Link")
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page.Tags...)
+
+		// 下一页：Link: </v2/<name>/tags/list?n=100&last=x>; rel="next"
+		endpoint = ""
+		if start, end := strings.Index(link, "<"), strings.Index(link, ">"); start >= 0 && end > start && strings.Contains(link, `rel="next"`) {
+			next := link[start+1 : end]
+			if strings.HasPrefix(next, "/") {
+				next = base + next
+			}
+			endpoint = next
+		}
+	}
+	Logger.Debug("✔️ Found %d tag(s) for %s", len(tags), ref)
+	return tags, nil
+}
+
+// Digest 获取标签当前指向的 manifest digest
+func (c *registryClient) Digest(ctx context.Context, ref imageRef, tag string) (string, error) {
+	endpoint := fmt.Sprintf("%s/v2/%s/manifests/%s", c.baseURL(ref.Registry), ref.Name, tag)
+	header := http.Header{}
+	header.Set("Accept", strings.Join([]string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}, ", "))
+
+	resp, err := c.do(ctx, ref, "HEAD", endpoint, header)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", upstreamStatusError("registry", endpoint, resp.StatusCode)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry did not return a digest for %s:%s", ref, tag)
+	}
+	Logger.Debug("✔️ %s:%s -> %s", ref, tag, digest)
+	return digest, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		in   string
+		want imageRef
+		ok   bool
+	}{
+		{"docker.io/nginx", imageRef{Registry: "docker.io", Name: "library/nginx"}, true},
+		{"index.docker.io/library/nginx:1.25", imageRef{Registry: "docker.io", Name: "library/nginx", Tag: "1.25"}, true},
+		{"ghcr.io/owner/app:latest", imageRef{Registry: "ghcr.io", Name: "owner/app", Tag: "latest"}, true},
+		{"localhost:5000/app", imageRef{Registry: "localhost:5000", Name: "app"}, true},
+		{"localhost/app", imageRef{Registry: "localhost", Name: "app"}, true},
+		{"nginx", imageRef{}, false},
+		{"owner/app", imageRef{}, false},
+		{"ghcr.io/owner/app:", imageRef{}, false},
+		{"ghcr.io/Owner/App", imageRef{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseImageRef(tt.in)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseImageRef(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	tests := []struct {
+		in   string
+		want map[string]string
+	}{
+		{
+			`realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"`,
+			map[string]string{"realm": "https://auth.docker.io/token", "service": "registry.docker.io", "scope": "repository:library/nginx:pull"},
+		},
+		{
+			`Realm="https://ghcr.io/token", Service="ghcr.io"`,
+			map[string]string{"realm": "https://ghcr.io/token", "service": "ghcr.io"},
+		},
+		{
+			`realm=https://example.com/token,service=example`,
+			map[string]string{"realm": "https://example.com/token", "service": "example"},
+		},
+		{
+			`scope="repository:a:pull,push",realm="https://example.com/token"`,
+			map[string]string{"scope": "repository:a:pull,push", "realm": "https://example.com/token"},
+		},
+		{`realm="unterminated`, map[string]string{"realm": "unterminated"}},
+		{"", map[string]string{}},
+	}
+	for _, tt := range tests {
+		if got := parseAuthChallenge(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseAuthChallenge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// newTestRegistry 启动一个需要 Bearer Token 的本地镜像仓库：app 有三个标签（分两页返回），latest 指向固定 digest
+func newTestRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if got := r.URL.Query().Get("scope"); !strings.HasPrefix(got, "repository:") || !strings.HasSuffix(got, ":pull") {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"token": "secret", "expires_in": 300})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/app/tags/list":
+			if r.URL.Query().Get("last") == "" {
+				w.Header().Set("Link", `</v2/app/tags/list?n=2&last=v1.1.0>; rel="next"`)
+				json.NewEncoder(w).Encode(map[string]any{"tags": []string{"v1.0.0", "v1.1.0"}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"tags": []string{"latest"}})
+		case "/v2/app/manifests/latest":
+			w.Header().Set("Docker-Content-Digest", "sha256:0123")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRegistryClient(t *testing.T) {
+	srv := newTestRegistry(t)
+	client := newRegistryClient(srv.Client(), "")
+	host := strings.TrimPrefix(srv.URL, "http://")
+	ctx := context.Background()
+
+	ref, ok := parseImageRef(host + "/app")
+	if !ok {
+		t.Fatalf("parseImageRef(%q) failed", host+"/app")
+	}
+	tags, err := client.ListTags(ctx, ref)
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if want := []string{"v1.0.0", "v1.1.0", "latest"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("ListTags = %v, want %v", tags, want)
+	}
+
+	digest, err := client.Digest(ctx, ref, "latest")
+	if err != nil {
+		t.Fatalf("Digest: %v", err)
+	}
+	if digest != "sha256:0123" {
+		t.Errorf("Digest = %q, want sha256:0123", digest)
+	}
+
+	missing, _ := parseImageRef(host + "/missing")
+	_, err = client.ListTags(ctx, missing)
+	if !isNotFound(err) || !isPermanentError(err) {
+		t.Errorf("ListTags(missing) error = %v, want a permanent not-found error", err)
+	}
+	_, err = client.Digest(ctx, ref, "nope")
+	if !isNotFound(err) {
+		t.Errorf("Digest(nope) error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"container/heap"
+	"log"
+	"time"
+)
+
+// scheduleItem 调度队列中的一项：某个分组的下次检查时间
+type scheduleItem struct {
+	key   checkGroupKey
+	due   time.Time
+	index int
+}
+
+// checkResult 一个分组检查任务的结果
+type checkResult struct {
+	key     checkGroupKey
+	updates []subUpdate
+}
+
+// scheduleQueue 按下次检查时间排序的优先队列（最小堆）
+type scheduleQueue []*scheduleItem
+
+func (q scheduleQueue) Len() int           { return len(q) }
+func (q scheduleQueue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x interface{}) {
+	item := x.(*scheduleItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *scheduleQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// scheduledChecker 定时检查器
+// 订阅按 (仓库, 分支, 事件类型) 分组，每个分组按组内最短的检查间隔进入优先队列，
+// 到期的分组交给 worker 池检查，同组订阅共享一次上游请求和一次翻译；workers: 并发检查的 worker 数量
+func scheduledChecker(tg *telegramClient, adminID int64, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	time.Sleep(initialDelay)
+
+	jobs := make(chan checkJob)
+	results := make(chan checkResult)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for job := range jobs {
+				results <- checkResult{key: job.key, updates: runCheckJob(tg, adminID, job)}
+			}
+		}()
+	}
+
+	var (
+		queue   scheduleQueue
+		queued  = make(map[checkGroupKey]*scheduleItem) // 已在队列中的分组
+		running = make(map[checkGroupKey]bool)          // 正在检查的分组
+		groups  = make(map[checkGroupKey][]repoConfig)  // 最近一次加载配置后的分组成员
+		known   = make(map[int64]bool)                  // 已参与调度的订阅，新订阅所在的分组立即检查
+		pending = make(map[int64]repoConfig)            // 尚未写回的检查结果
+		current = make(map[int64]repoConfig)            // 最近一次加载的配置
+		ready   []checkJob                              // 已到期、等待 worker 的分组任务
+	)
+
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+
+	// tick 写回结果、重新加载配置并派发到期的分组
+	tick := func() {
+		flushCheckResults(pending)
+		pending = make(map[int64]repoConfig)
+
+		configs, err := loadConfigs()
+		if err != nil {
+			log.Printf("Failed to load configs: %v", err)
+			return
+		}
+		current = make(map[int64]repoConfig, len(configs))
+		now := time.Now()
+		var missingCreated bool
+		for _, cfg := range configs {
+			if cfg.Created == 0 {
+				cfg.Created = now.Unix()
+				missingCreated = true
+			}
+			current[cfg.ID] = cfg
+		}
+		if missingCreated {
+			backfillCreated(now)
+		}
+		for id := range known {
+			if _, ok := current[id]; !ok {
+				delete(known, id)
+			}
+		}
+
+		groups = make(map[checkGroupKey][]repoConfig)
+		for _, job := range groupCheckJobs(configs) {
+			groups[job.key] = job.subs
+			if running[job.key] {
+				// 检查中的分组完成后再排队，新加入的订阅随下一次检查初始化
+				continue
+			}
+			fresh := false
+			for _, sub := range job.subs {
+				if !known[sub.ID] {
+					fresh = true
+					known[sub.ID] = true
+				}
+			}
+			// 新分组或有新订阅加入的分组立即检查
+			if item, ok := queued[job.key]; !ok {
+				item = &scheduleItem{key: job.key, due: now}
+				heap.Push(&queue, item)
+				queued[job.key] = item
+			} else if fresh && item.due.After(now) {
+				item.due = now
+				heap.Fix(&queue, item.index)
+			}
+		}
+
+		var due []checkJob
+		for queue.Len() > 0 && !queue[0].due.After(now) {
+			item := heap.Pop(&queue).(*scheduleItem)
+			delete(queued, item.key)
+			subs, ok := groups[item.key]
+			if !ok {
+				// 分组已没有订阅（删除、暂停或修改了分支）
+				continue
+			}
+			running[item.key] = true
+			due = append(due, checkJob{key: item.key, subs: subs})
+		}
+		if len(due) == 0 {
+			return
+		}
+		ready = append(ready, due...)
+		Logger.Debug("📦 %d group(s) due, %d queued", len(due), queue.Len())
+	}
+
+	tick()
+	for {
+		// 没有待派发任务时禁用发送分支
+		var out chan<- checkJob
+		var next checkJob
+		if len(ready) > 0 {
+			out = jobs
+			next = ready[0]
+		}
+
+		select {
+		case out <- next:
+			ready = ready[1:]
+		case res := <-results:
+			now := time.Now()
+			delete(running, res.key)
+			permanent := false
+			for _, u := range res.updates {
+				if u.err != nil && isPermanentError(u.err) {
+					permanent = true
+				}
+				if !u.changed {
+					continue
+				}
+				cfg, ok := pending[u.id]
+				if !ok {
+					cfg = current[u.id]
+				}
+				applyCheckState(&cfg, &u)
+				pending[u.id] = cfg
+			}
+
+			subs, ok := groups[res.key]
+			if !ok {
+				continue
+			}
+			// 按组内最短的检查间隔重新排队
+			interval := groupCheckInterval(subs, pending, now)
+			if permanent {
+				// 永久性错误（仓库已删除、不可访问等）重试无意义，降低检查频率
+				interval = permanentErrorInterval
+				log.Printf("⛔ %s returned a permanent error, backing off for %s", res.key.repo, interval)
+			}
+			item := &scheduleItem{key: res.key, due: now.Add(interval)}
+			heap.Push(&queue, item)
+			queued[res.key] = item
+			Logger.Debug("⏱️ Next %s check for %s in %s", res.key.event, res.key.repo, interval)
+		case <-ticker.C:
+			tick()
+		}
+	}
+}
+
+// groupCheckInterval 返回组内订阅最短的检查间隔（优先使用尚未写回的检查结果计算自适应间隔）
+func groupCheckInterval(subs []repoConfig, pending map[int64]repoConfig, now time.Time) time.Duration {
+	interval := time.Duration(-1)
+	for _, sub := range subs {
+		if cfg, ok := pending[sub.ID]; ok {
+			sub = cfg
+		}
+		if d := nextCheckInterval(&sub, now); interval < 0 || d < interval {
+			interval = d
+		}
+	}
+	if interval < 0 {
+		return checkInterval
+	}
+	return interval
+}
+
+// flushCheckResults 将检查结果写回配置文件
+// 检查期间配置可能被 /add、/delete 修改，这里基于最新配置合并状态字段
+func flushCheckResults(updated map[int64]repoConfig) {
+	if len(updated) == 0 {
+		return
+	}
+	Logger.Debug("🔄 Saving config updates...")
+	err := updateConfigs(func(current []repoConfig) ([]repoConfig, bool) {
+		changed := false
+		for i := range current {
+			if res, ok := updated[current[i].ID]; ok {
+				mergeCheckState(&current[i], &res)
+				changed = true
+			}
+		}
+		return current, changed
+	})
+	if err != nil {
+		log.Printf("❌ Failed to save configs: %v", err)
+	}
+}
+
+// backfillCreated 为新订阅和旧版本配置补齐创建时间
+func backfillCreated(now time.Time) {
+	err := updateConfigs(func(current []repoConfig) ([]repoConfig, bool) {
+		changed := false
+		for i := range current {
+			if current[i].Created == 0 {
+				current[i].Created = now.Unix()
+				changed = true
+			}
+		}
+		return current, changed
+	})
+	if err != nil {
+		log.Printf("❌ Failed to save configs: %v", err)
+	}
+}
+
+// nextCheckInterval 计算订阅的下次检查间隔
+// 自适应模式下根据最近事件的平均间隔调整：活跃仓库更频繁，冷门仓库更稀疏；
+// 从未检测到事件时按订阅以来的时长计算，一直没有动静的仓库逐渐降到 adaptiveMaxInterval
+func nextCheckInterval(cfg *repoConfig, now time.Time) time.Duration {
+	base := checkInterval
+	if cfg.CheckInterval != "" {
+		if d, err := time.ParseDuration(cfg.CheckInterval); err == nil && d >= minCheckInterval {
+			base = d
+		}
+	}
+	if !cfg.Adaptive {
+		return base
+	}
+
+	var avgGap time.Duration
+	if events := cfg.EventTimes; len(events) > 0 {
+		// 平均事件间隔：历史事件之间的间隔 + 距最近一次事件的时间
+		last := time.Unix(events[len(events)-1], 0)
+		span := now.Sub(time.Unix(events[0], 0))
+		avgGap = span / time.Duration(len(events))
+		if since := now.Sub(last); since > avgGap {
+			avgGap = since
+		}
+	} else if cfg.Created > 0 {
+		avgGap = now.Sub(time.Unix(cfg.Created, 0))
+	} else {
+		return base
+	}
+
+	// 期望每个事件周期内检查约 adaptivePollsPerEvent 次
+	interval := avgGap / adaptivePollsPerEvent
+	if interval < adaptiveMinInterval {
+		interval = adaptiveMinInterval
+	}
+	if interval > adaptiveMaxInterval {
+		interval = adaptiveMaxInterval
+	}
+	return interval
+}
+
+// recordEvent 记录一次检测到的事件（用于自适应轮询）
+func recordEvent(cfg *repoConfig, at time.Time) {
+	cfg.EventTimes = append(cfg.EventTimes, at.Unix())
+	if len(cfg.EventTimes) > maxEventHistory {
+		cfg.EventTimes = cfg.EventTimes[len(cfg.EventTimes)-maxEventHistory:]
+	}
+}
+
+// mergeCheckState 将检查结果中的状态字段合并到配置
+// 检查期间分支（镜像为监控的标签）被修改时，结果中旧分支的提交和 digest 不再适用，保留修改时重置的状态
+func mergeCheckState(dst, src *repoConfig) {
+	dst.LastReleaseID = src.LastReleaseID
+	dst.LastReleaseTag = src.LastReleaseTag
+	if dst.Branch == "" || dst.Branch == src.Branch {
+		dst.LastCommitSHA = src.LastCommitSHA
+		dst.LastDigest = src.LastDigest
+	}
+	dst.EventTimes = src.EventTimes
+	dst.KnownTags = src.KnownTags
+	dst.LastVersion = src.LastVersion
+	dst.SeenItems = src.SeenItems
+	dst.StarFailures = src.StarFailures
+	dst.SeenRecorded = src.SeenRecorded
+	if dst.Branch == "" {
+		dst.Branch = src.Branch
+	}
+	if dst.RepoName == "" {
+		dst.RepoName = src.RepoName
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sendRequest 排队等待发送的请求
+type sendRequest struct {
+	method string
+	params url.Values
+	files  []uploadFile // 非空时以 multipart 上传
+	result interface{}
+	done   chan error
+}
+
+// sendQueue Telegram 发送队列
+// 每个会话一个队列按顺序发送，遵守单会话和全局的频率限制；
+// 429 限流按 retry_after 等待后重试，网络错误和 5xx 按指数退避重试
+type sendQueue struct {
+	client *telegramClient
+	global *rateLimiter
+
+	mu    sync.Mutex
+	lanes map[int64]chan *sendRequest
+}
+
+// newSendQueue 创建发送队列
+func newSendQueue(client *telegramClient) *sendQueue {
+	return &sendQueue{
+		client: client,
+		global: newRateLimiter(telegramGlobalInterval),
+		lanes:  make(map[int64]chan *sendRequest),
+	}
+}
+
+// send 将请求加入会话的队列并等待结果，返回 nil 表示 Telegram 已确认
+func (q *sendQueue) send(chatID int64, method string, params url.Values, result interface{}) error {
+	req := &sendRequest{method: method, params: params, result: result, done: make(chan error, 1)}
+	q.lane(chatID) <- req
+	return <-req.done
+}
+
+// upload 与 send 相同，但以 multipart 上传文件
+func (q *sendQueue) upload(chatID int64, method string, params url.Values, files []uploadFile, result interface{}) error {
+	req := &sendRequest{method: method, params: params, files: files, result: result, done: make(chan error, 1)}
+	q.lane(chatID) <- req
+	return <-req.done
+}
+
+// lane 返回会话的队列，首次使用时启动处理协程
+func (q *sendQueue) lane(chatID int64) chan *sendRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ch, ok := q.lanes[chatID]
+	if !ok {
+		ch = make(chan *sendRequest, 64)
+		q.lanes[chatID] = ch
+		go q.run(chatID, ch)
+	}
+	return ch
+}
+
+// run 按顺序处理一个会话的请求，相邻两条之间至少间隔该会话的频率限制
+func (q *sendQueue) run(chatID int64, ch chan *sendRequest) {
+	// 负数 ID 为群组和频道，限制更严格
+	interval := telegramPrivateInterval
+	if chatID < 0 {
+		interval = telegramGroupInterval
+	}
+	var last time.Time
+	for req := range ch {
+		if wait := interval - time.Since(last); wait > 0 {
+			time.Sleep(wait)
+		}
+		req.done <- q.deliver(chatID, interval, req)
+		last = time.Now()
+	}
+}
+
+// deliver 发送请求，遇到限流或临时错误时重试
+// MarkdownV2 解析失败时保存原始内容便于排查转义问题，再以纯文本重新发送（同样限速和重试），避免通知丢失
+func (q *sendQueue) deliver(chatID int64, interval time.Duration, req *sendRequest) error {
+	var err error
+	resend := false
+	for attempt := 0; attempt <= telegramMaxRetries; attempt++ {
+		if resend {
+			time.Sleep(interval)
+			resend = false
+		} else if attempt > 0 {
+			delay := telegramBackoff(attempt, err)
+			if delay > telegramMaxRetryAfter {
+				log.Printf("⏳ Telegram asks %d to wait %s, giving up for now", chatID, delay)
+				return err
+			}
+			log.Printf("⏳ Retrying %s to %d in %s (attempt %d/%d): %v", req.method, chatID, delay, attempt, telegramMaxRetries, err)
+			time.Sleep(delay)
+		}
+		q.global.Wait(context.Background())
+		err = q.do(req)
+		var tgErr *telegramError
+		if errors.As(err, &tgErr) && tgErr.MigrateToChatID != 0 {
+			// 群组已升级为超级群组，改用新 ID 立即重发
+			newID := tgErr.MigrateToChatID
+			log.Printf("🔀 Chat %d migrated to supergroup %d, resending %s", chatID, newID, req.method)
+			if q.client.onMigrate != nil {
+				go q.client.onMigrate(chatID, newID)
+			}
+			req.params.Set("chat_id", strconv.FormatInt(newID, 10))
+			err = q.do(req)
+		}
+		if tgErr, ok := parseEntitiesError(err, req.params); ok {
+			log.Printf("⚠️ Telegram could not parse %s entities, resending as plain text: %s", req.method, tgErr.Description)
+			recordParseError(req.method, req.params, tgErr)
+			req.params = plainTextParams(req.params)
+			// 纯文本重发不占用重试次数，但同样遵守会话的发送间隔
+			resend = true
+			attempt--
+			continue
+		}
+		if err == nil || !isRetryableTelegramError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// do 调用一次 Telegram API
+func (q *sendQueue) do(req *sendRequest) error {
+	if len(req.files) > 0 {
+		return q.client.upload(req.method, req.params, req.files, req.result)
+	}
+	return q.client.call(req.method, req.params, req.result)
+}
+
+// isRetryableTelegramError 判断发送失败是否值得重试：限流、服务端错误和网络错误
+func isRetryableTelegramError(err error) bool {
+	var tgErr *telegramError
+	if errors.As(err, &tgErr) {
+		return tgErr.Code == 429 || tgErr.Code >= 500
+	}
+	return true
+}
+
+// telegramBackoff 计算第 attempt 次重试前的等待时间，限流时使用 Telegram 返回的 retry_after
+func telegramBackoff(attempt int, lastErr error) time.Duration {
+	var tgErr *telegramError
+	if errors.As(lastErr, &tgErr) && tgErr.RetryAfter > 0 {
+		return tgErr.RetryAfter
+	}
+	ceiling := telegramBaseBackoff << uint(attempt-1)
+	if ceiling > telegramMaxBackoff {
+		ceiling = telegramMaxBackoff
+	}
+	return ceiling/2 + time.Duration(rand.Int63n(int64(ceiling/2)+1))
+}
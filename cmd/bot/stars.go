@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// starSession Star/Watch 导入的选择会话（保存在内存中，重启后失效）
+type starSession struct {
+	id        int64
+	chatID    int64
+	messageID int
+	user      string // 为空表示 Token 所有者 Watch 的仓库
+	repos     []string
+	selected  map[int]bool
+	page      int
+	tgChat    *chat
+	opts      *addOptions
+	sync      bool // 导入后持续同步新 Star
+	created   time.Time
+}
+
+var starSessions = struct {
+	sync.Mutex
+	next     int64
+	sessions map[int64]*starSession
+}{sessions: make(map[int64]*starSession)}
+
+// describeStarSource 返回 Star 来源的展示名称（未转义）
+func describeStarSource(user string) string {
+	if user == "" {
+		return "watching"
+	}
+	return "stars:" + user
+}
+
+// handleStars 处理 /stars 命令：列出用户 Star（或 Token 所有者 Watch）的仓库，通过内联键盘勾选后批量订阅
+// 格式：/stars [用户名] [@group] [-s]，-s 表示之后持续同步新 Star
+func handleStars(tg *telegramClient, chatID int64, text string) {
+	args := strings.Fields(text)[1:]
+	user := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") && !strings.HasPrefix(args[0], "@") {
+		user, args = args[0], args[1:]
+	}
+	syncStars := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "-s" {
+			syncStars = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	opts, errMsg := parseAddOptions(rest)
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	tgChat, errMsg := resolveNotifyChat(tg, opts.chatTarget)
+	if errMsg != "" {
+		tg.sendMessage(chatID, errMsg, telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	repos, err := githubAPI.StarredRepos(ctx, user)
+	if err != nil {
+		log.Printf("Failed to list starred repos for %q: %v", user, err)
+		tg.sendMessage(chatID, Messages.ErrorInvalidRepo(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+	if len(repos) == 0 {
+		tg.sendMessage(chatID, Messages.StarsEmpty(), telegramParseModeMarkdown, false, "", 0)
+		return
+	}
+
+	s := &starSession{
+		chatID:   chatID,
+		user:     user,
+		repos:    repos,
+		selected: make(map[int]bool),
+		tgChat:   tgChat,
+		opts:     opts,
+		sync:     syncStars,
+		created:  time.Now(),
+	}
+	starSessions.Lock()
+	for id, old := range starSessions.sessions {
+		if time.Since(old.created) > starSessionTTL {
+			delete(starSessions.sessions, id)
+		}
+	}
+	starSessions.next++
+	s.id = starSessions.next
+	starSessions.sessions[s.id] = s
+	starSessions.Unlock()
+
+	text, markup := renderStarSession(s)
+	msg, err := tg.sendMessage(chatID, text, telegramParseModeMarkdown, true, markup, 0)
+	if err != nil {
+		return
+	}
+	starSessions.Lock()
+	s.messageID = msg.MessageID
+	starSessions.Unlock()
+}
+
+// renderStarSession 生成选择消息和内联键盘（调用方需保证会话不被并发修改）
+func renderStarSession(s *starSession) (string, string) {
+	pages := (len(s.repos) + starsPerPage - 1) / starsPerPage
+	data := func(action string, args ...int) string {
+		parts := []string{"stars", strconv.FormatInt(s.id, 10), action}
+		for _, a := range args {
+			parts = append(parts, strconv.Itoa(a))
+		}
+		return strings.Join(parts, ":")
+	}
+
+	var rows [][]inlineKeyboardButton
+	start := s.page * starsPerPage
+	for i := start; i < start+starsPerPage && i < len(s.repos); i++ {
+		mark := "⬜"
+		if s.selected[i] {
+			mark = "✅"
+		}
+		rows = append(rows, []inlineKeyboardButton{{Text: mark + " " + s.repos[i], CallbackData: data("t", i)}})
+	}
+
+	var nav []inlineKeyboardButton
+	if s.page > 0 {
+		nav = append(nav, inlineKeyboardButton{Text: "◀️", CallbackData: data("p", s.page-1)})
+	}
+	if s.page < pages-1 {
+		nav = append(nav, inlineKeyboardButton{Text: "▶️", CallbackData: data("p", s.page+1)})
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+	rows = append(rows,
+		[]inlineKeyboardButton{
+			{Text: "全选本页", CallbackData: data("a")},
+			{Text: "清空", CallbackData: data("n")},
+		},
+		[]inlineKeyboardButton{
+			{Text: fmt.Sprintf("✅ 订阅（%d）", len(s.selected)), CallbackData: data("ok")},
+			{Text: "✖️ 取消", CallbackData: data("x")},
+		},
+	)
+
+	text := Messages.StarsSelect(describeStarSource(s.user), len(s.repos), len(s.selected), s.page+1, pages, s.sync)
+	return text, inlineKeyboard(rows)
+}
+
+// handleStarsCallback 处理选择键盘的按钮：t 勾选、p 翻页、a 全选本页、n 清空、ok 确认、x 取消
+func handleStarsCallback(tg *telegramClient, cq *callbackQuery, args []string) {
+	if len(args) < 2 {
+		tg.answerCallbackQuery(cq.ID, "")
+		return
+	}
+	id, _ := strconv.ParseInt(args[0], 10, 64)
+	action := args[1]
+	arg := -1
+	if len(args) > 2 {
+		arg, _ = strconv.Atoi(args[2])
+	}
+
+	starSessions.Lock()
+	s, ok := starSessions.sessions[id]
+	if !ok || time.Since(s.created) > starSessionTTL {
+		delete(starSessions.sessions, id)
+		starSessions.Unlock()
+		tg.answerCallbackQuery(cq.ID, Messages.CallbackExpired())
+		return
+	}
+
+	switch action {
+	case "t":
+		if arg >= 0 && arg < len(s.repos) {
+			if s.selected[arg] {
+				delete(s.selected, arg)
+			} else {
+				s.selected[arg] = true
+			}
+		}
+	case "p":
+		if arg >= 0 && arg*starsPerPage < len(s.repos) {
+			s.page = arg
+		}
+	case "a":
+		for i := s.page * starsPerPage; i < (s.page+1)*starsPerPage && i < len(s.repos); i++ {
+			s.selected[i] = true
+		}
+	case "n":
+		s.selected = make(map[int]bool)
+	case "x":
+		delete(starSessions.sessions, id)
+		starSessions.Unlock()
+		tg.answerCallbackQuery(cq.ID, "")
+		tg.editMessageText(s.chatID, s.messageID, Messages.StarsCancelled(), telegramParseModeMarkdown, "")
+		return
+	case "ok":
+		if len(s.selected) == 0 && !s.sync {
+			starSessions.Unlock()
+			tg.answerCallbackQuery(cq.ID, Messages.CallbackNothingSelected())
+			return
+		}
+		delete(starSessions.sessions, id)
+		starSessions.Unlock()
+		tg.answerCallbackQuery(cq.ID, "")
+		tg.editMessageText(s.chatID, s.messageID, Messages.StarsAdding(len(s.selected)), telegramParseModeMarkdown, "")
+		go importStarSession(tg, s)
+		return
+	}
+	text, markup := renderStarSession(s)
+	starSessions.Unlock()
+
+	tg.answerCallbackQuery(cq.ID, "")
+	tg.editMessageText(s.chatID, s.messageID, text, telegramParseModeMarkdown, markup)
+}
+
+// importStarSession 为选中的仓库添加 Release 订阅，按需创建 Star 同步订阅
+func importStarSession(tg *telegramClient, s *starSession) {
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	var repos []string
+	for i, repo := range s.repos {
+		if s.selected[i] {
+			repos = append(repos, repo)
+		}
+	}
+	added, existing, failed, err := addReleaseSubscriptions(ctx, tg, s.tgChat, repos, s.opts)
+	if err != nil {
+		log.Printf("Failed to save configs: %v", err)
+		tg.editMessageText(s.chatID, s.messageID, Messages.ErrorUnexpected(), telegramParseModeMarkdown, "")
+		return
+	}
+
+	report := Messages.ImportReport(describeNotifyChat(s.tgChat), added, existing, failed)
+	if s.sync {
+		if err := addStarSync(s); err != nil {
+			log.Printf("Failed to save star sync for %q: %v", s.user, err)
+		} else {
+			report = MDV2.JoinLines(report, "", Messages.StarsSyncEnabled(describeStarSource(s.user)))
+		}
+	}
+	tg.editMessageText(s.chatID, s.messageID, report, telegramParseModeMarkdown, "")
+	log.Printf("⭐ Imported %d starred repositories for %q (%d existing, %d failed)", len(added), s.user, existing, len(failed))
+}
+
+// addStarSync 保存 Star 同步订阅：当前列表全部记为已处理，之后新 Star 的仓库自动添加 Release 订阅
+func addStarSync(s *starSession) error {
+	cfg := repoConfig{
+		Source:        sourceStars,
+		Repo:          s.user,
+		CheckInterval: starsSyncInterval,
+		ShowCommits:   s.opts.showCommits,
+		AssetFilter:   s.opts.assetFilter,
+		AssetMaxSize:  s.opts.assetMaxSize,
+		QuietHours:    s.opts.quietHours,
+		QuietMode:     s.opts.quietMode,
+		SeenItems:     append([]string{}, s.repos...),
+		SeenRecorded:  true,
+	}
+	if s.opts.checkInterval != "" {
+		cfg.CheckInterval = s.opts.checkInterval
+	}
+	setNotifyChat(&cfg, s.tgChat)
+
+	return updateConfigs(func(current []repoConfig) ([]repoConfig, bool) {
+		for i := range current {
+			if current[i].Source == sourceStars && strings.EqualFold(current[i].Repo, cfg.Repo) && current[i].ChannelID == cfg.ChannelID {
+				return current, false
+			}
+		}
+		cfg.ID = nextConfigID(current)
+		return append(current, cfg), true
+	})
+}
@@ -2,11 +2,15 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -18,19 +22,113 @@ type telegramResponse struct {
 	Result      json.RawMessage `json:"result"`
 	Description string          `json:"description"`
 	ErrorCode   int             `json:"error_code"`
+	Parameters  *struct {
+		RetryAfter      int   `json:"retry_after"`
+		MigrateToChatID int64 `json:"migrate_to_chat_id"`
+	} `json:"parameters"`
+}
+
+// telegramError Telegram API 返回的错误
+type telegramError struct {
+	Method          string
+	Code            int
+	Description     string
+	RetryAfter      time.Duration // 429 限流时需要等待的时间
+	MigrateToChatID int64         // 群组已升级为超级群组时的新 ID
+}
+
+func (e *telegramError) Error() string {
+	return fmt.Sprintf("telegram api error %d: %s", e.Code, e.Description)
 }
 
 // Telegram 消息相关结构
 type update struct {
-	UpdateID int      `json:"update_id"`
-	Message  *message `json:"message"`
+	UpdateID      int                `json:"update_id"`
+	Message       *message           `json:"message"`
+	CallbackQuery *callbackQuery     `json:"callback_query"`
+	MyChatMember  *chatMemberUpdated `json:"my_chat_member"`
+	InlineQuery   *inlineQuery       `json:"inline_query"`
+}
+
+// inlineQuery 内联查询（在任意会话中输入 @bot 查询内容）
+type inlineQuery struct {
+	ID    string `json:"id"`
+	From  *user  `json:"from"`
+	Query string `json:"query"`
+}
+
+// inlineQueryResult 内联查询结果（InlineQueryResultArticle）
+type inlineQueryResult struct {
+	Type                string                  `json:"type"`
+	ID                  string                  `json:"id"`
+	Title               string                  `json:"title"`
+	Description         string                  `json:"description,omitempty"`
+	URL                 string                  `json:"url,omitempty"`
+	InputMessageContent inputTextMessageContent `json:"input_message_content"`
+}
+
+// inputTextMessageContent 选择结果后发送的消息内容
+type inputTextMessageContent struct {
+	MessageText           string `json:"message_text"`
+	ParseMode             string `json:"parse_mode,omitempty"`
+	DisableWebPagePreview bool   `json:"disable_web_page_preview,omitempty"`
+}
+
+// chatMemberUpdated 机器人在会话中的成员状态变化
+type chatMemberUpdated struct {
+	Chat          *chat      `json:"chat"`
+	From          *user      `json:"from"`
+	OldChatMember chatMember `json:"old_chat_member"`
+	NewChatMember chatMember `json:"new_chat_member"`
+}
+
+// callbackQuery 内联键盘按钮回调
+type callbackQuery struct {
+	ID      string   `json:"id"`
+	From    *user    `json:"from"`
+	Message *message `json:"message"`
+	Data    string   `json:"data"`
+}
+
+// inlineKeyboardButton 内联键盘按钮
+type inlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// inlineKeyboard 生成 reply_markup 参数（内联键盘）
+func inlineKeyboard(rows [][]inlineKeyboardButton) string {
+	data, _ := json.Marshal(struct {
+		InlineKeyboard [][]inlineKeyboardButton `json:"inline_keyboard"`
+	}{rows})
+	return string(data)
 }
 
 type message struct {
-	MessageID int    `json:"message_id"`
-	From      *user  `json:"from"`
-	Chat      *chat  `json:"chat"`
-	Text      string `json:"text"`
+	MessageID int       `json:"message_id"`
+	From      *user     `json:"from"`
+	Chat      *chat     `json:"chat"`
+	Text      string    `json:"text"`
+	Caption   string    `json:"caption"`
+	Document  *document `json:"document"`
+
+	// 群组升级为超级群组时旧群组中的服务消息
+	MigrateToChatID int64 `json:"migrate_to_chat_id"`
+}
+
+// document 消息中的文件
+type document struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name"`
+	FileSize int64  `json:"file_size"`
+}
+
+// telegramFile getFile 返回的文件信息
+type telegramFile struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size"`
+	FilePath string `json:"file_path"`
 }
 
 type user struct {
@@ -49,7 +147,11 @@ type chat struct {
 }
 
 type chatMember struct {
-	User user `json:"user"`
+	User            user   `json:"user"`
+	Status          string `json:"status"` // creator/administrator/member/restricted/left/kicked
+	IsMember        bool   `json:"is_member"`
+	CanPostMessages bool   `json:"can_post_messages"` // 频道管理员是否可以发消息
+	CanSendMessages bool   `json:"can_send_messages"` // 受限成员是否可以发消息
 }
 
 // telegramClient Telegram 客户端
@@ -57,14 +159,25 @@ type telegramClient struct {
 	baseURL    string
 	httpClient *http.Client
 	botID      int64
+	queue      *sendQueue
+
+	// uploadClient 上传文件使用，超时更长
+	uploadClient *http.Client
+
+	// onMigrate 发送时发现群组已升级为超级群组后调用（异步），用于更新订阅中的会话 ID
+	onMigrate func(oldID, newID int64)
 }
 
 // newTelegramClient 创建新的 Telegram 客户端
 func newTelegramClient(token string) *telegramClient {
-	return &telegramClient{
+	c := &telegramClient{
 		baseURL:    "https://api.telegram.org/bot" + token + "/",
 		httpClient: &http.Client{Timeout: 65 * time.Second},
+
+		uploadClient: &http.Client{Timeout: assetUploadTimeout},
 	}
+	c.queue = newSendQueue(c)
+	return c
 }
 
 // call 调用 Telegram API
@@ -84,6 +197,35 @@ func (c *telegramClient) call(method string, params url.Values, result interface
 	}
 	defer resp.Body.Close()
 
+	return decodeResponse(method, resp, result)
+}
+
+// parseEntitiesError 判断请求是否因 Telegram 无法解析 MarkdownV2 而失败
+func parseEntitiesError(err error, params url.Values) (*telegramError, bool) {
+	var tgErr *telegramError
+	if errors.As(err, &tgErr) && tgErr.Code == 400 && strings.Contains(tgErr.Description, "can't parse entities") && params.Get("parse_mode") == telegramParseModeMarkdown {
+		return tgErr, true
+	}
+	return nil, false
+}
+
+// plainTextParams 将 MarkdownV2 请求参数转换为纯文本（去掉 parse_mode，正文和说明转为纯文本）
+func plainTextParams(params url.Values) url.Values {
+	plain := url.Values{}
+	for key, values := range params {
+		plain[key] = values
+	}
+	plain.Del("parse_mode")
+	for _, key := range []string{"text", "caption"} {
+		if v := params.Get(key); v != "" {
+			plain.Set(key, MDV2.PlainText(v))
+		}
+	}
+	return plain
+}
+
+// decodeResponse 解析 Telegram API 响应，失败时返回 *telegramError
+func decodeResponse(method string, resp *http.Response, result interface{}) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
@@ -91,10 +233,19 @@ func (c *telegramClient) call(method string, params url.Values, result interface
 
 	var apiResp telegramResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
+		// 代理或网关返回的非 JSON 错误页（如上传过大时的 HTML 413）按 HTTP 状态码分类，4xx 不再重试
+		if resp.StatusCode >= 400 {
+			return &telegramError{Method: method, Code: resp.StatusCode, Description: resp.Status}
+		}
 		return err
 	}
 	if !apiResp.Ok {
-		return fmt.Errorf("telegram api error %d: %s", apiResp.ErrorCode, apiResp.Description)
+		tgErr := &telegramError{Method: method, Code: apiResp.ErrorCode, Description: apiResp.Description}
+		if apiResp.Parameters != nil {
+			tgErr.RetryAfter = time.Duration(apiResp.Parameters.RetryAfter) * time.Second
+			tgErr.MigrateToChatID = apiResp.Parameters.MigrateToChatID
+		}
+		return tgErr
 	}
 	if result != nil {
 		if err := json.Unmarshal(apiResp.Result, result); err != nil {
@@ -104,6 +255,131 @@ func (c *telegramClient) call(method string, params url.Values, result interface
 	return nil
 }
 
+// uploadFile multipart 上传的文件
+// open 在写入请求体时才调用（重试时会再次调用），返回的内容边读边上传，不整体读入内存
+type uploadFile struct {
+	field string
+	name  string
+	open  func() (io.ReadCloser, error)
+}
+
+// upload 以 multipart/form-data 调用 Telegram API 上传文件
+func (c *telegramClient) upload(method string, params url.Values, files []uploadFile, result interface{}) error {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, params, files))
+	}()
+
+	req, err := http.NewRequest("POST", c.baseURL+method, pr)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp, err := c.uploadClient.Do(req)
+	if err != nil {
+		// 去掉错误中带 Token 的请求地址
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("%s: %w", method, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeResponse(method, resp, result)
+}
+
+// writeMultipart 依次写入表单字段和文件内容
+func writeMultipart(mw *multipart.Writer, params url.Values, files []uploadFile) error {
+	for key, values := range params {
+		for _, v := range values {
+			if err := mw.WriteField(key, v); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range files {
+		part, err := mw.CreateFormFile(f.field, f.name)
+		if err != nil {
+			return err
+		}
+		r, err := f.open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// sendDocuments 将文件作为回复 replyTo 的文档发送：一个文件用 sendDocument，多个（最多 10 个）用 sendMediaGroup；silent 时静默发送
+func (c *telegramClient) sendDocuments(chatID, threadID int64, replyTo int, files []uploadFile, silent bool) error {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	if threadID > 0 {
+		params.Set("message_thread_id", strconv.FormatInt(threadID, 10))
+	}
+	if replyTo > 0 {
+		params.Set("reply_parameters", fmt.Sprintf(`{"message_id":%d,"allow_sending_without_reply":true}`, replyTo))
+	}
+	if silent {
+		params.Set("disable_notification", "true")
+	}
+	Logger.Debug("📎 Uploading %d document(s) to %d (topic: %d)", len(files), chatID, threadID)
+
+	if len(files) == 1 {
+		files[0].field = "document"
+		return c.queue.upload(chatID, "sendDocument", params, files, nil)
+	}
+	type inputMedia struct {
+		Type  string `json:"type"`
+		Media string `json:"media"`
+	}
+	var media []inputMedia
+	for i := range files {
+		files[i].field = fmt.Sprintf("file%d", i)
+		media = append(media, inputMedia{Type: "document", Media: "attach://" + files[i].field})
+	}
+	data, err := json.Marshal(media)
+	if err != nil {
+		return err
+	}
+	params.Set("media", string(data))
+	return c.queue.upload(chatID, "sendMediaGroup", params, files, nil)
+}
+
+// recordParseError 将无法解析的 MarkdownV2 内容保存到 parseErrorDir，只保留最近 maxParseErrorDumps 个
+func recordParseError(method string, params url.Values, tgErr *telegramError) {
+	if err := os.MkdirAll(parseErrorDir, 0755); err != nil {
+		log.Printf("⚠️ Failed to create %s: %v", parseErrorDir, err)
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "method: %s\nchat_id: %s\nerror: %s\n\n", method, params.Get("chat_id"), tgErr.Description)
+	b.WriteString(params.Get("text"))
+	b.WriteString(params.Get("caption"))
+	name := filepath.Join(parseErrorDir, time.Now().Format("20060102-150405.000000")+"-"+method+".txt")
+	if err := os.WriteFile(name, []byte(b.String()), 0644); err != nil {
+		log.Printf("⚠️ Failed to record parse error: %v", err)
+		return
+	}
+	log.Printf("📝 Failing payload saved to %s", name)
+
+	// 文件名按时间排序，删除最旧的
+	entries, err := os.ReadDir(parseErrorDir)
+	if err != nil {
+		return
+	}
+	for i := 0; i < len(entries)-maxParseErrorDumps; i++ {
+		os.Remove(filepath.Join(parseErrorDir, entries[i].Name()))
+	}
+}
+
 // getMe 获取机器人信息
 func (c *telegramClient) getMe() (*user, error) {
 	var me user
@@ -120,7 +396,7 @@ func (c *telegramClient) getUpdates(offset int) ([]update, error) {
 		params.Set("offset", strconv.Itoa(offset))
 	}
 	params.Set("timeout", "60")
-	params.Set("allowed_updates", `["message"]`)
+	params.Set("allowed_updates", telegramAllowedUpdates)
 
 	var updates []update
 	if err := c.call("getUpdates", params, &updates); err != nil {
@@ -129,9 +405,82 @@ func (c *telegramClient) getUpdates(offset int) ([]update, error) {
 	return updates, nil
 }
 
-// sendMessage 发送消息
+// setWebhook 设置 Webhook 地址，Telegram 推送时会在 X-Telegram-Bot-Api-Secret-Token 头中带上 secret
+func (c *telegramClient) setWebhook(webhookURL, secret string) error {
+	params := url.Values{}
+	params.Set("url", webhookURL)
+	params.Set("secret_token", secret)
+	params.Set("allowed_updates", telegramAllowedUpdates)
+	return c.call("setWebhook", params, nil)
+}
+
+// telegramBotCommand 命令菜单中的一项
+type telegramBotCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// setMyCommands 设置命令菜单；commands 为 JSON 数组，scope 为 BotCommandScope JSON，languageCode 为空表示默认语言
+func (c *telegramClient) setMyCommands(commands, scope, languageCode string) error {
+	params := url.Values{}
+	params.Set("commands", commands)
+	params.Set("scope", scope)
+	if languageCode != "" {
+		params.Set("language_code", languageCode)
+	}
+	return c.call("setMyCommands", params, nil)
+}
+
+// deleteWebhook 删除 Webhook（切回长轮询时需要）
+func (c *telegramClient) deleteWebhook() error {
+	return c.call("deleteWebhook", nil, nil)
+}
+
+// sendMessage 发送消息，经发送队列限速，限流和临时错误会自动重试，返回 nil 错误表示已送达
 // threadID: 群组话题 ID，为 0 时不指定话题
+// 超过 Telegram 长度限制时拆分为多条发送（最多 maxMessageParts 条，其余截断），内联键盘附在最后一条；返回第一条消息
 func (c *telegramClient) sendMessage(chatID int64, text, parseMode string, disablePreview bool, replyMarkup string, threadID int64) (*message, error) {
+	return c.sendMessageParts(chatID, text, parseMode, disablePreview, replyMarkup, threadID, false)
+}
+
+// sendNotification 发送订阅通知（MarkdownV2，不显示链接预览），silent 时静默发送不提醒
+// 超长通知拆分为多条；sent 为之前已送达的各条消息 ID，从第一条未送达的继续发送，
+// 返回已送达的全部消息 ID（失败时也返回，供下次重试继续）
+func (c *telegramClient) sendNotification(chatID, threadID int64, text string, silent bool, sent []int) ([]int, error) {
+	parts := splitMessage(text, telegramParseModeMarkdown)
+	sent = append([]int(nil), sent...)
+	for i := len(sent); i < len(parts); i++ {
+		msg, err := c.sendMessagePart(chatID, parts[i], telegramParseModeMarkdown, true, "", threadID, silent)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, msg.MessageID)
+	}
+	return sent, nil
+}
+
+// sendMessageParts 拆分并逐条发送消息，返回第一条消息
+func (c *telegramClient) sendMessageParts(chatID int64, text, parseMode string, disablePreview bool, replyMarkup string, threadID int64, silent bool) (*message, error) {
+	parts := splitMessage(text, parseMode)
+	var first *message
+	for i, part := range parts {
+		markup := ""
+		if i == len(parts)-1 {
+			markup = replyMarkup
+		}
+		msg, err := c.sendMessagePart(chatID, part, parseMode, disablePreview, markup, threadID, silent)
+		if err != nil {
+			return first, err
+		}
+		if first == nil {
+			first = msg
+		}
+	}
+	return first, nil
+}
+
+// sendMessagePart 调用 sendMessage 发送一条不超过长度限制的消息
+func (c *telegramClient) sendMessagePart(chatID int64, text, parseMode string, disablePreview bool, replyMarkup string, threadID int64, silent bool) (*message, error) {
 	Logger.Debug("💬 Sending message to %d (topic: %d, %d chars)", chatID, threadID, len(text))
 	params := url.Values{}
 	params.Set("chat_id", strconv.FormatInt(chatID, 10))
@@ -148,8 +497,11 @@ func (c *telegramClient) sendMessage(chatID int64, text, parseMode string, disab
 	if threadID > 0 {
 		params.Set("message_thread_id", strconv.FormatInt(threadID, 10))
 	}
+	if silent {
+		params.Set("disable_notification", "true")
+	}
 	var msg message
-	if err := c.call("sendMessage", params, &msg); err != nil {
+	if err := c.queue.send(chatID, "sendMessage", params, &msg); err != nil {
 		log.Printf("❌ Telegram sendMessage failed: %v", err)
 		return nil, err
 	}
@@ -157,6 +509,155 @@ func (c *telegramClient) sendMessage(chatID int64, text, parseMode string, disab
 	return &msg, nil
 }
 
+// splitMessage 按 Telegram 长度限制拆分消息
+// 超过 maxMessageParts 条时截断，并在最后一条保留原消息的末行（通常是「查看详情」链接）
+func splitMessage(text, parseMode string) []string {
+	split := splitPlainText
+	if parseMode == telegramParseModeMarkdown {
+		split = MDV2.Split
+	}
+	parts := split(text, maxMessageLength)
+	if len(parts) <= maxMessageParts {
+		return parts
+	}
+
+	footer := Messages.MessageTruncated()
+	if parseMode != telegramParseModeMarkdown {
+		footer = "…"
+	}
+	if i := strings.LastIndex(text, "\n"); i >= 0 && parseMode == telegramParseModeMarkdown {
+		if last := text[i+1:]; strings.Contains(last, "](") && utf16Len(last) <= 300 {
+			footer = MDV2.JoinLines(footer, last)
+		}
+	}
+	log.Printf("⚠️ Message too long (%d chars), truncated to %d parts", utf16Len(text), maxMessageParts)
+	parts = split(text, maxMessageLength-utf16Len(footer)-2)[:maxMessageParts]
+	parts[maxMessageParts-1] += "\n\n" + footer
+	return parts
+}
+
+// splitPlainText 拆分纯文本消息，优先在换行处拆分
+func splitPlainText(text string, limit int) []string {
+	var parts []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		if utf16Len(string(runes)) <= limit {
+			parts = append(parts, string(runes))
+			break
+		}
+		cut, size := 0, 0
+		for cut < len(runes) && size+utf16Len(string(runes[cut])) <= limit {
+			size += utf16Len(string(runes[cut]))
+			cut++
+		}
+		for j := cut - 1; j > cut/2; j-- {
+			if runes[j] == '\n' {
+				cut = j + 1
+				break
+			}
+		}
+		parts = append(parts, strings.TrimRight(string(runes[:cut]), "\n"))
+		runes = runes[cut:]
+	}
+	return parts
+}
+
+// editMessageText 编辑已发送的消息（用于内联键盘交互）
+// 超长内容按 splitMessage 拆分，第一部分（带键盘）替换原消息，其余部分作为新消息发送
+func (c *telegramClient) editMessageText(chatID int64, messageID int, text, parseMode, replyMarkup string) error {
+	parts := splitMessage(text, parseMode)
+	if err := c.editMessagePart(chatID, messageID, parts[0], parseMode, replyMarkup); err != nil {
+		return err
+	}
+	for _, part := range parts[1:] {
+		if _, err := c.sendMessagePart(chatID, part, parseMode, true, "", 0, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// editMessagePart 编辑已发送的消息，text 不超过单条消息长度上限
+func (c *telegramClient) editMessagePart(chatID int64, messageID int, text, parseMode, replyMarkup string) error {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("message_id", strconv.Itoa(messageID))
+	params.Set("text", text)
+	if parseMode != "" {
+		params.Set("parse_mode", parseMode)
+	}
+	params.Set("disable_web_page_preview", "true")
+	if replyMarkup != "" {
+		params.Set("reply_markup", replyMarkup)
+	}
+	if err := c.queue.send(chatID, "editMessageText", params, nil); err != nil {
+		// 内容未变化不算失败
+		if strings.Contains(err.Error(), "message is not modified") {
+			return nil
+		}
+		log.Printf("❌ Telegram editMessageText failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// answerInlineQuery 响应内联查询，results 为结果 JSON 数组；结果只对查询者本人缓存 cacheTime 秒
+func (c *telegramClient) answerInlineQuery(queryID, results string, cacheTime int) error {
+	params := url.Values{}
+	params.Set("inline_query_id", queryID)
+	params.Set("results", results)
+	params.Set("cache_time", strconv.Itoa(cacheTime))
+	params.Set("is_personal", "true")
+	return c.call("answerInlineQuery", params, nil)
+}
+
+// answerCallbackQuery 响应按钮回调，text 非空时在客户端弹出提示
+func (c *telegramClient) answerCallbackQuery(queryID, text string) error {
+	params := url.Values{}
+	params.Set("callback_query_id", queryID)
+	if text != "" {
+		params.Set("text", text)
+	}
+	return c.call("answerCallbackQuery", params, nil)
+}
+
+// downloadFile 通过 getFile 下载用户发送的文件（最多读取 maxSize 字节）
+func (c *telegramClient) downloadFile(fileID string, maxSize int64) ([]byte, error) {
+	params := url.Values{}
+	params.Set("file_id", fileID)
+	var f telegramFile
+	if err := c.call("getFile", params, &f); err != nil {
+		return nil, err
+	}
+	if f.FileSize > maxSize {
+		return nil, fmt.Errorf("file too large: %d bytes", f.FileSize)
+	}
+
+	// 文件下载地址：https://api.telegram.org/file/bot<token>/<file_path>
+	fileURL := strings.Replace(c.baseURL, "/bot", "/file/bot", 1) + f.FilePath
+	resp, err := c.httpClient.Get(fileURL)
+	if err != nil {
+		// 错误信息中的 URL 含有 Bot Token，只保留底层错误
+		if uerr, ok := err.(*url.Error); ok {
+			err = uerr.Err
+		}
+		return nil, fmt.Errorf("telegram file download failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("telegram file download returned status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file too large: more than %d bytes", maxSize)
+	}
+	Logger.Debug("📥 Downloaded %s (%d bytes)", f.FilePath, len(data))
+	return data, nil
+}
+
 // getChat 获取频道/群聊信息
 func (c *telegramClient) getChat(chatIDOrUsername string) (*chat, error) {
 	params := url.Values{}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// githubTokenState 单个 Token 的额度状态
+type githubTokenState struct {
+	token     string
+	limit     int
+	remaining int // -1 表示尚未从响应头获知
+	reset     time.Time
+	requests  int64
+	invalid   bool // 收到 401，Token 已失效
+}
+
+// githubTokenPool 多个 GitHub Token 组成的额度池
+// 每次请求选择剩余额度最多的 Token，遇到 401 / 限流时自动切换
+type githubTokenPool struct {
+	mu     sync.Mutex
+	tokens []*githubTokenState
+}
+
+// tokenUsage Token 用量快照（用于向管理员汇报）
+type tokenUsage struct {
+	Label     string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Requests  int64
+	Invalid   bool
+}
+
+// githubTokens 全局 Token 池（未配置 Token 时为 nil）
+var githubTokens *githubTokenPool
+
+// newGitHubTokenPool 创建 Token 池，自动去除空值和重复项
+func newGitHubTokenPool(tokens []string) *githubTokenPool {
+	pool := &githubTokenPool{}
+	seen := make(map[string]bool)
+	for _, t := range tokens {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		pool.tokens = append(pool.tokens, &githubTokenState{token: t, remaining: -1})
+	}
+	if len(pool.tokens) == 0 {
+		return nil
+	}
+	return pool
+}
+
+// headroom 返回 Token 当前可用额度的估计值
+func (s *githubTokenState) headroom(now time.Time) int {
+	if s.invalid {
+		return -1
+	}
+	// 未知额度或已过重置时间，按满额计算
+	if s.remaining < 0 || (!s.reset.IsZero() && now.After(s.reset)) {
+		if s.limit > 0 {
+			return s.limit
+		}
+		return 5000
+	}
+	return s.remaining
+}
+
+// tokensUnavailableError 池中没有可用的 Token（全部失效或额度耗尽），不会退回匿名请求
+type tokensUnavailableError struct {
+	reset time.Time // 最早恢复额度的时间，全部失效时为零值
+}
+
+func (e *tokensUnavailableError) Error() string {
+	if e.reset.IsZero() {
+		return "all GitHub tokens are invalid"
+	}
+	return fmt.Sprintf("all GitHub tokens are rate limited until %s", e.reset.Format(time.RFC3339))
+}
+
+// pick 选择剩余额度最多的 Token，跳过 tried 中已尝试过的
+// 没有可用的 Token 时返回 *tokensUnavailableError
+func (p *githubTokenPool) pick(tried map[string]bool) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var (
+		best     *githubTokenState
+		bestRoom int
+		reset    time.Time
+	)
+	for _, s := range p.tokens {
+		if tried[s.token] {
+			continue
+		}
+		room := s.headroom(now)
+		if room > bestRoom {
+			best, bestRoom = s, room
+		}
+		if room == 0 && (reset.IsZero() || s.reset.Before(reset)) {
+			reset = s.reset
+		}
+	}
+	if best == nil {
+		return "", &tokensUnavailableError{reset: reset}
+	}
+	best.requests++
+	return best.token, nil
+}
+
+// find 查找 Token 对应的状态（调用方需持有 p.mu）
+func (p *githubTokenPool) find(token string) *githubTokenState {
+	for _, s := range p.tokens {
+		if s.token == token {
+			return s
+		}
+	}
+	return nil
+}
+
+// record 根据响应头更新 Token 额度
+func (p *githubTokenPool) record(token string, resp *http.Response) {
+	remaining, err1 := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	limit, err2 := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	reset, err3 := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.find(token)
+	if s == nil {
+		return
+	}
+	if err1 == nil {
+		s.remaining = remaining
+	}
+	if err2 == nil {
+		s.limit = limit
+	}
+	if err3 == nil {
+		s.reset = time.Unix(reset, 0)
+	}
+}
+
+// markInvalid 标记 Token 已失效（401）
+func (p *githubTokenPool) markInvalid(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s := p.find(token); s != nil {
+		s.invalid = true
+	}
+}
+
+// markExhausted 标记 Token 额度已耗尽，直到 reset 时间
+func (p *githubTokenPool) markExhausted(token string, reset time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s := p.find(token); s != nil {
+		s.remaining = 0
+		if !reset.IsZero() {
+			s.reset = reset
+		}
+	}
+}
+
+// usage 返回所有 Token 的用量快照，按剩余额度降序
+func (p *githubTokenPool) usage() []tokenUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	list := make([]tokenUsage, 0, len(p.tokens))
+	for _, s := range p.tokens {
+		list = append(list, tokenUsage{
+			Label:     maskToken(s.token),
+			Limit:     s.limit,
+			Remaining: s.remaining,
+			Reset:     s.reset,
+			Requests:  s.requests,
+			Invalid:   s.invalid,
+		})
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Remaining > list[j].Remaining })
+	return list
+}
+
+// maskToken 隐藏 Token 中间部分，仅保留前缀和末 4 位
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	prefix := ""
+	if i := strings.LastIndex(token, "_"); i >= 0 && i < 12 {
+		prefix = token[:i+1]
+	}
+	return prefix + "…" + token[len(token)-4:]
+}
+
+// bearerToken 从请求头中取出 Bearer Token
+func bearerToken(req *http.Request) string {
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+}
+
+// isRateLimited 判断 403/429 响应是否由限流导致
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != ""
+}
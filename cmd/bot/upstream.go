@@ -0,0 +1,41 @@
+package main
+
+import "fmt"
+
+// upstreamError 非 GitHub 上游（镜像仓库、包注册表、订阅源）的请求错误
+// 与 gitHubError 使用相同的永久性/临时性分类，调度器据此降低检查频率
+type upstreamError struct {
+	Kind      string // 上游类型，用于日志，如 registry、package registry、feed
+	Endpoint  string
+	Status    int // 网络错误时为 0
+	Permanent bool
+	Err       error
+}
+
+func (e *upstreamError) Error() string {
+	kind := "transient"
+	if e.Permanent {
+		kind = "permanent"
+	}
+	if e.Status != 0 {
+		return fmt.Sprintf("%s %s error: status %d for %s", kind, e.Kind, e.Status, e.Endpoint)
+	}
+	return fmt.Sprintf("%s %s error for %s: %v", kind, e.Kind, e.Endpoint, e.Err)
+}
+
+func (e *upstreamError) Unwrap() error { return e.Err }
+
+// upstreamStatusError 将非 2xx 响应转换为错误
+func upstreamStatusError(kind, endpoint string, status int) error {
+	return &upstreamError{
+		Kind:      kind,
+		Endpoint:  endpoint,
+		Status:    status,
+		Permanent: isPermanentStatus(status),
+	}
+}
+
+// upstreamNetworkError 包装请求上游时的网络错误
+func upstreamNetworkError(kind, endpoint string, err error) error {
+	return &upstreamError{Kind: kind, Endpoint: endpoint, Err: err}
+}
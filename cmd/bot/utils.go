@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
 // parseCommand 解析命令
@@ -21,56 +24,237 @@ func parseCommand(text string) string {
 	return cmd
 }
 
-// buildRepoListMessage 构建仓库列表消息
-func buildRepoListMessage() (string, error) {
+// buildRepoListMessage 构建仓库列表消息（分页），返回消息文本和内联键盘
+// 序号为全局序号，与 /delete 保持一致；点击按钮进入对应订阅的管理界面
+func buildRepoListMessage(page int) (string, string, error) {
 	configs, err := loadConfigs()
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	if len(configs) == 0 {
-		return Messages.ListEmpty(), nil
+		return Messages.ListEmpty(), "", nil
+	}
+
+	pages := (len(configs) + listPageSize - 1) / listPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
 	}
 
 	var builder strings.Builder
 	builder.WriteString(Messages.ListHeader())
+	if pages > 1 {
+		builder.WriteString(" " + MDV2.Escape(fmt.Sprintf("（第 %d/%d 页）", page+1, pages)))
+	}
 	builder.WriteString("\n\n")
-	
-	for i, cfg := range configs {
-		// 分支信息（非 main 分支才显示）
-		branchInfo := ""
-		if cfg.MonitorCommit && cfg.Branch != "" && cfg.Branch != "main" {
-			branchInfo = cfg.Branch
+
+	var rows [][]inlineKeyboardButton
+	var row []inlineKeyboardButton
+	start := page * listPageSize
+	for i := start; i < start+listPageSize && i < len(configs); i++ {
+		cfg := &configs[i]
+		builder.WriteString(buildRepoListItem(i+1, cfg))
+		builder.WriteString("\n\n")
+
+		row = append(row, inlineKeyboardButton{
+			Text:         fmt.Sprintf("%d", i+1),
+			CallbackData: fmt.Sprintf("list:v:%d:%d", cfg.ID, page),
+		})
+		if len(row) == 5 {
+			rows = append(rows, row)
+			row = nil
 		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	var nav []inlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, inlineKeyboardButton{Text: "◀️", CallbackData: fmt.Sprintf("list:pg:%d", page-1)})
+	}
+	if page < pages-1 {
+		nav = append(nav, inlineKeyboardButton{Text: "▶️", CallbackData: fmt.Sprintf("list:pg:%d", page+1)})
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	return strings.TrimSpace(builder.String()), inlineKeyboard(rows), nil
+}
+
+// buildRepoListItem 构建单个订阅的列表项
+func buildRepoListItem(index int, cfg *repoConfig) string {
+	// 分支信息（非 main 分支才显示）
+	branchInfo := describeBranch(cfg, false)
 
-		// 通知目标
-		target := "私聊"
-		if cfg.ChannelID != 0 {
-			channelTitle := strings.TrimSpace(cfg.ChannelTitle)
-			if channelTitle != "" {
-				target = MDV2.Escape(channelTitle)
-			} else {
-				target = "群组"
-			}
-			// 如果有话题，显示 群组 > 话题名（话题名就是仓库名）
-			if cfg.ThreadID > 0 {
-				target = fmt.Sprintf("%s \\> %s", target, MDV2.Escape(cfg.RepoName))
-			}
+	// 通知目标
+	target := "私聊"
+	if cfg.ChannelID != 0 {
+		channelTitle := strings.TrimSpace(cfg.ChannelTitle)
+		if channelTitle != "" {
+			target = MDV2.Escape(channelTitle)
+		} else {
+			target = "群组"
 		}
+		// 如果有话题，显示 群组 > 话题名（话题名就是仓库名）
+		if cfg.ThreadID > 0 {
+			target = fmt.Sprintf("%s \\> %s", target, MDV2.Escape(cfg.RepoName))
+		}
+	}
 
-		// 监控类型
-		var monitorType string
-		if cfg.MonitorRelease && cfg.MonitorCommit {
-			monitorType = "Release \\+ Commit"
-		} else if cfg.MonitorRelease {
-			monitorType = "Release"
-		} else if cfg.MonitorCommit {
-			monitorType = "Commit"
+	// 监控类型
+	monitorType := describeMonitorType(cfg)
+
+	// 构建列表项
+	var extras []string
+	if cfg.AutoPaused {
+		extras = append(extras, Messages.ListItemExtra("状态", "⏸️ 已暂停（机器人无法在该会话发消息）"))
+	} else if cfg.Paused {
+		extras = append(extras, Messages.ListItemExtra("状态", "⏸️ 已暂停"))
+	}
+	if cfg.TagFilter != "" {
+		extras = append(extras, Messages.ListItemExtra("标签", MDV2.Code(cfg.TagFilter)))
+	}
+	if cfg.ShowCommits {
+		extras = append(extras, Messages.ListItemExtra("附带", "提交记录"))
+	}
+	if cfg.AssetFilter != "" {
+		limit := cfg.AssetMaxSize
+		if limit <= 0 {
+			limit = telegramMaxUploadSize
 		}
+		extras = append(extras, Messages.ListItemExtra("附件", MDV2.Nbsp(MDV2.Code(cfg.AssetFilter), MDV2.Escape("≤ "+formatSize(limit)))))
+	}
+	if quiet := describeQuietHours(cfg); quiet != "" {
+		extras = append(extras, Messages.ListItemExtra("免打扰", MDV2.Escape(quiet)))
+	}
+	if interval := describeInterval(cfg); interval != "" {
+		extras = append(extras, Messages.ListItemExtra("频率", interval))
+	}
+	return Messages.ListItem(index, MDV2.EscapeCode(subscriptionName(cfg)), branchInfo, monitorType, target, extras...)
+}
 
-		// 构建列表项
-		builder.WriteString(Messages.ListItem(i+1, MDV2.Escape(cfg.Repo), branchInfo, monitorType, target))
-		builder.WriteString("\n\n")
+// shortDigest 缩短 digest 用于展示，如 sha256:0123456789ab
+func shortDigest(digest string) string {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algo + ":" + hex[:12]
+}
+
+// subscriptionName 返回订阅的展示名称，语言包带上来源前缀（如 npm:react）
+func subscriptionName(cfg *repoConfig) string {
+	if _, ok := packageRegistries[cfg.Source]; ok {
+		return cfg.Source + ":" + cfg.Repo
+	}
+	if cfg.Source == sourceStars {
+		return describeStarSource(cfg.Repo)
+	}
+	return cfg.Repo
+}
+
+// describeMonitorType 描述订阅的监控类型（MarkdownV2 已转义）
+func describeMonitorType(cfg *repoConfig) string {
+	if registry, ok := packageRegistries[cfg.Source]; ok {
+		return "版本（" + MDV2.Escape(registry.Label) + "）"
+	}
+	if cfg.Source == sourceFeed {
+		return "订阅源"
+	}
+	if cfg.Source == sourceStars {
+		return "新 Star 同步"
+	}
+	if cfg.Source == sourceImage {
+		if cfg.WatchDigest {
+			return "镜像标签 \\+ Digest"
+		}
+		return "镜像标签"
+	}
+	if cfg.MonitorRelease && cfg.MonitorCommit {
+		return "Release \\+ Commit"
+	} else if cfg.MonitorRelease {
+		return "Release"
+	} else if cfg.MonitorCommit {
+		return "Commit"
+	}
+	return ""
+}
+
+// describeBranch 返回需要展示的分支（镜像为监控 digest 的标签），不需要展示时返回空字符串
+// includeDefault 为 false 时不展示 main 分支
+func describeBranch(cfg *repoConfig, includeDefault bool) string {
+	if cfg.Source == sourceImage {
+		if cfg.WatchDigest {
+			return cfg.Branch
+		}
+		return ""
+	}
+	if !cfg.MonitorCommit || cfg.Branch == "" {
+		return ""
+	}
+	if !includeDefault && cfg.Branch == "main" {
+		return ""
+	}
+	return cfg.Branch
+}
+
+// describeInterval 描述订阅的检查频率（MarkdownV2 已转义），默认频率返回空字符串
+func describeInterval(cfg *repoConfig) string {
+	interval := cfg.CheckInterval
+	if interval != "" {
+		interval = MDV2.CodeRaw(interval)
+	}
+	switch {
+	case cfg.Adaptive && interval != "":
+		return "自适应（基准 " + interval + "）"
+	case cfg.Adaptive:
+		return "自适应"
+	default:
+		return interval
+	}
+}
+
+// rateLimiter 简单的全局限速器，保证相邻两次放行至少间隔 interval
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter 创建限速器
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{interval: interval}
+}
+
+// Wait 阻塞直到允许下一次请求，ctx 取消时提前返回
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleepContext(ctx, wait)
+}
+
+// sleepContext 等待 d，ctx 取消时提前返回 ctx.Err()
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	
-	return strings.TrimSpace(builder.String()), nil
 }
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parsedVersion 解析后的版本号
+// 兼容 SemVer（v1.2.3-rc.1+build）和 PEP 440 常见写法（1.2.3rc1、1.2.3.dev1、1.2.3.post1）
+type parsedVersion struct {
+	release    []int
+	prerelease []string // 为空表示正式版
+	post       int      // PEP 440 post 版本号，-1 表示不是 post 版本
+}
+
+// parseVersion 解析版本号，无法识别数字部分时返回 false
+func parseVersion(v string) (parsedVersion, bool) {
+	v = strings.TrimSpace(v)
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	// 构建元数据不参与比较
+	if i := strings.IndexAny(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+
+	pv := parsedVersion{post: -1}
+	rest := v
+	for {
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return parsedVersion{}, false
+		}
+		pv.release = append(pv.release, n)
+		rest = rest[end:]
+		if !strings.HasPrefix(rest, ".") || len(rest) < 2 || rest[1] < '0' || rest[1] > '9' {
+			break
+		}
+		rest = rest[1:]
+	}
+	if len(pv.release) == 0 {
+		return parsedVersion{}, false
+	}
+
+	// 剩余部分为预发布标识：-rc.1、rc1、.dev1、-beta 等；.post1（-r1、rev1 同义）为 post 版本，排在正式版之后
+	rest = strings.TrimLeft(rest, "-._")
+	if rest == "" {
+		return pv, true
+	}
+	parts := splitPrerelease(rest)
+	switch strings.ToLower(parts[0]) {
+	case "post", "rev", "r":
+		pv.post = 0
+		if len(parts) > 1 {
+			if n, err := strconv.Atoi(parts[1]); err == nil {
+				pv.post = n
+			}
+		}
+	default:
+		pv.prerelease = parts
+	}
+	return pv, true
+}
+
+// splitPrerelease 将预发布标识拆分为字母段和数字段，如 rc.1 / rc1 -> [rc 1]
+func splitPrerelease(s string) []string {
+	var parts []string
+	var cur strings.Builder
+	isDigit := func(b byte) bool { return b >= '0' && b <= '9' }
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '.' || c == '-' || c == '_' {
+			if cur.Len() > 0 {
+				parts = append(parts, cur.String())
+				cur.Reset()
+			}
+			continue
+		}
+		if cur.Len() > 0 && isDigit(c) != isDigit(cur.String()[cur.Len()-1]) {
+			parts = append(parts, cur.String())
+			cur.Reset()
+		}
+		cur.WriteByte(c)
+	}
+	if cur.Len() > 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
+
+// isPrerelease 判断版本是否为预发布版本
+func isPrerelease(v string) bool {
+	pv, ok := parseVersion(v)
+	return ok && len(pv.prerelease) > 0
+}
+
+// compareVersions 比较两个版本号，a < b 返回 -1，相等返回 0，a > b 返回 1
+// 无法解析的版本号排在可解析版本之前，彼此之间按字符串比较
+func compareVersions(a, b string) int {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := 0; i < len(pa.release) || i < len(pb.release); i++ {
+		var x, y int
+		if i < len(pa.release) {
+			x = pa.release[i]
+		}
+		if i < len(pb.release) {
+			y = pb.release[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// 预发布版本 < 正式版 < post 版本
+	if sa, sb := pa.stage(), pb.stage(); sa != sb {
+		if sa < sb {
+			return -1
+		}
+		return 1
+	}
+	switch {
+	case pa.post != pb.post:
+		if pa.post < pb.post {
+			return -1
+		}
+		return 1
+	case len(pa.prerelease) == 0:
+		return 0
+	}
+	for i := 0; i < len(pa.prerelease) && i < len(pb.prerelease); i++ {
+		if c := comparePrereleasePart(pa.prerelease[i], pb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(pa.prerelease) < len(pb.prerelease):
+		return -1
+	case len(pa.prerelease) > len(pb.prerelease):
+		return 1
+	}
+	return 0
+}
+
+// stage 版本的阶段：预发布版本为 0，正式版为 1，post 版本为 2
+func (pv parsedVersion) stage() int {
+	switch {
+	case len(pv.prerelease) > 0:
+		return 0
+	case pv.post >= 0:
+		return 2
+	}
+	return 1
+}
+
+// comparePrereleasePart 比较预发布标识中的一段：数字按数值比较，且小于字母段
+func comparePrereleasePart(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	rankA, okA := prereleaseRanks[a]
+	rankB, okB := prereleaseRanks[b]
+	if okA && okB && rankA != rankB {
+		if rankA < rankB {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// prereleaseRanks 常见预发布标识的先后顺序：dev < alpha < beta < rc
+var prereleaseRanks = map[string]int{
+	"dev": 0, "snapshot": 0,
+	"a": 1, "alpha": 1,
+	"b": 2, "beta": 2,
+	"c": 3, "rc": 3, "pre": 3, "preview": 3,
+}
+
+// latestVersion 返回版本列表中的最新版本，优先正式版；列表为空时返回空字符串
+func latestVersion(versions []string) string {
+	latest, latestPre := "", ""
+	for _, v := range versions {
+		if isPrerelease(v) {
+			if latestPre == "" || compareVersions(v, latestPre) > 0 {
+				latestPre = v
+			}
+		} else if latest == "" || compareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	if latest == "" {
+		return latestPre
+	}
+	return latest
+}
+
+// sortVersionsDesc 将版本号按从新到旧排序
+func sortVersionsDesc(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want parsedVersion
+		ok   bool
+	}{
+		{"1.2.3", parsedVersion{release: []int{1, 2, 3}, post: -1}, true},
+		{"v1.2", parsedVersion{release: []int{1, 2}, post: -1}, true},
+		{"V2", parsedVersion{release: []int{2}, post: -1}, true},
+		{"1.2.3+build.5", parsedVersion{release: []int{1, 2, 3}, post: -1}, true},
+		{"v1.2.3-rc.1", parsedVersion{release: []int{1, 2, 3}, prerelease: []string{"rc", "1"}, post: -1}, true},
+		{"1.2.3rc1", parsedVersion{release: []int{1, 2, 3}, prerelease: []string{"rc", "1"}, post: -1}, true},
+		{"1.2.3.dev4", parsedVersion{release: []int{1, 2, 3}, prerelease: []string{"dev", "4"}, post: -1}, true},
+		{"1.2.3.post1", parsedVersion{release: []int{1, 2, 3}, post: 1}, true},
+		{"1.2.3-r2", parsedVersion{release: []int{1, 2, 3}, post: 2}, true},
+		{"1.2.3.post", parsedVersion{release: []int{1, 2, 3}, post: 0}, true},
+		{"latest", parsedVersion{}, false},
+		{"", parsedVersion{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseVersion(tt.in)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseVersion(%q) = %+v, %v; want %+v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.10.0", "1.9.0", 1},
+		{"1.2.3", "1.2.4", -1},
+		{"1.2.3-rc.1", "1.2.3", -1},
+		{"1.2.3-rc.2", "1.2.3-rc.10", -1},
+		{"1.2.3a1", "1.2.3b1", -1},
+		{"1.2.3b2", "1.2.3rc1", -1},
+		{"1.2.3.dev1", "1.2.3a1", -1},
+		{"1.2.3-beta", "1.2.3-beta.1", -1},
+		{"1.2.3.post1", "1.2.3", 1},
+		{"1.2.3.post1", "1.2.3.post2", -1},
+		{"1.2.3.post1", "1.2.4rc1", -1},
+		{"1.2.3-r1", "1.2.3-rc1", 1},
+		{"latest", "1.0.0", -1},
+		{"edge", "latest", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"1.0.0", "2.0.0-rc.1", "1.1.0"}, "1.1.0"},
+		{[]string{"2.0.0-rc.1", "2.0.0-beta.3"}, "2.0.0-rc.1"},
+		{[]string{"1.0.0", "1.0.0.post1"}, "1.0.0.post1"},
+	}
+	for _, tt := range tests {
+		if got := latestVersion(tt.in); got != tt.want {
+			t.Errorf("latestVersion(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSortVersionsDesc(t *testing.T) {
+	versions := []string{"v1.0.0", "latest", "v1.10.0", "v1.2.0", "v1.10.0-rc.1", "v1.2.0.post1"}
+	sortVersionsDesc(versions)
+	want := []string{"v1.10.0", "v1.10.0-rc.1", "v1.2.0.post1", "v1.2.0", "v1.0.0", "latest"}
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("sortVersionsDesc = %v, want %v", versions, want)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// webhookSecretRegexp Telegram 对 secret_token 的格式要求
+var webhookSecretRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// runWebhook 设置 Webhook 并启动 HTTP 服务接收 Telegram 推送的更新
+// webhookURL 为公网地址（通常经过反向代理），其路径即为本地监听的路径；secret 为空时随机生成
+func runWebhook(tg *telegramClient, adminID int64, webhookURL, listen, secret string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("WEBHOOK_URL must be an https URL, got %q", webhookURL)
+	}
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return err
+		}
+		secret = hex.EncodeToString(buf)
+	} else if !webhookSecretRegexp.MatchString(secret) {
+		return fmt.Errorf("WEBHOOK_SECRET may only contain A-Z, a-z, 0-9, _ and - (1-256 characters)")
+	}
+
+	// 更新按顺序逐条处理，与长轮询行为一致；HTTP 处理函数只负责入队，尽快响应 Telegram
+	queue := make(chan update, webhookQueueSize)
+	go func() {
+		for upd := range queue {
+			handleUpdate(tg, upd, adminID)
+		}
+	}()
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, webhookHandler(secret, queue))
+
+	if err := tg.setWebhook(webhookURL, secret); err != nil {
+		return fmt.Errorf("setWebhook: %w", err)
+	}
+	log.Printf("📡 Receiving updates via webhook %s (listening on %s)", webhookURL, listen)
+	return http.ListenAndServe(listen, mux)
+}
+
+// webhookHandler 校验 X-Telegram-Bot-Api-Secret-Token 后解析更新并入队
+func webhookHandler(secret string, queue chan<- update) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			log.Printf("⚠️ Rejected webhook request from %s: invalid secret token", r.RemoteAddr)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+		if err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var upd update
+		if err := json.Unmarshal(body, &upd); err != nil {
+			log.Printf("⚠️ Invalid webhook payload: %v", err)
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case queue <- upd:
+			w.WriteHeader(http.StatusOK)
+		default:
+			// 队列已满时让 Telegram 稍后重试
+			log.Printf("⚠️ Webhook queue full, dropping update %d for retry", upd.UpdateID)
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		}
+	}
+}